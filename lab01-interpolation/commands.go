@@ -0,0 +1,143 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// command - подкоманда программы со своим набором флагов
+type command struct {
+	name        string
+	description string
+	run         func(args []string) error
+}
+
+// commands - подкоманды, доступные через первый аргумент командной строки
+var commands = []command{
+	{"interp", "интерполяция функции и сравнение методов (по умолчанию)", runInterp},
+	{"integrate", "вычисление интеграла функции квадратурными формулами", runIntegrate},
+	{"roots", "поиск корня функции на отрезке", runRoots},
+}
+
+// defaultCommand выполняется, если подкоманда не указана (первый аргумент - флаг или отсутствует)
+const defaultCommand = "interp"
+
+// findCommand возвращает подкоманду с именем name
+func findCommand(name string) (*command, error) {
+	for i := range commands {
+		if commands[i].name == name {
+			return &commands[i], nil
+		}
+	}
+	return nil, fmt.Errorf("неизвестная подкоманда %q", name)
+}
+
+// printCommands выводит список подкоманд с описаниями
+func printCommands(w io.Writer) {
+	fmt.Fprintf(w, "Использование: %s [подкоманда] [флаги]\n\nПодкоманды:\n", os.Args[0])
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", c.name, c.description)
+	}
+}
+
+func main() {
+	name, args := defaultCommand, os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+
+	cmd, err := findCommand(name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка: %v\n\n", err)
+		printCommands(os.Stderr)
+		os.Exit(2)
+	}
+	if err := cmd.run(args); err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runIntegrate выполняет подкоманду integrate: сравнивает квадратурные формулы
+// и интеграл кубического сплайна по n+1 равномерным узлам
+func runIntegrate(args []string) error {
+	fs := flag.NewFlagSet("integrate", flag.ExitOnError)
+	aFlag := fs.Float64("a", 1.0, "левая граница интервала")
+	bFlag := fs.Float64("b", 5.0, "правая граница интервала")
+	nFlag := fs.Int("n", 10, "количество отрезков сетки для интеграла сплайна")
+	funcFlag := fs.String("func", "test",
+		"интегрируемая функция: "+strings.Join(functionNames(), ", ")+" или выражение от x")
+	fs.Parse(args)
+
+	a, b := *aFlag, *bFlag
+	if a >= b {
+		usageError(fs, fmt.Errorf("левая граница a = %g должна быть меньше правой b = %g", a, b))
+	}
+	if *nFlag < 1 {
+		usageError(fs, fmt.Errorf("количество отрезков должно быть не меньше 1, получено %d", *nFlag))
+	}
+	f, err := resolveFunction(*funcFlag)
+	if err != nil {
+		usageError(fs, err)
+	}
+
+	data, err := createGrid(a, b, *nFlag, f)
+	if err != nil {
+		return err
+	}
+	compareQuadratures(data, f)
+	return nil
+}
+
+// runRoots выполняет подкоманду roots: ищет корень функции на [a, b] всеми реализованными
+// методами. Производная для метода Ньютона оценивается центральной разностью
+func runRoots(args []string) error {
+	fs := flag.NewFlagSet("roots", flag.ExitOnError)
+	aFlag := fs.Float64("a", 1.0, "левая граница отрезка, на котором ищется корень")
+	bFlag := fs.Float64("b", 5.0, "правая граница отрезка, на котором ищется корень")
+	tolFlag := fs.Float64("tol", 1e-10, "точность по x")
+	funcFlag := fs.String("func", "test",
+		"функция: "+strings.Join(functionNames(), ", ")+" или выражение от x")
+	fs.Parse(args)
+
+	a, b := *aFlag, *bFlag
+	if a >= b {
+		usageError(fs, fmt.Errorf("левая граница a = %g должна быть меньше правой b = %g", a, b))
+	}
+	if !(*tolFlag > 0) {
+		usageError(fs, fmt.Errorf("точность должна быть положительной, получено %g", *tolFlag))
+	}
+	f, err := resolveFunction(*funcFlag)
+	if err != nil {
+		usageError(fs, err)
+	}
+	tol := *tolFlag
+
+	df := func(x float64) float64 {
+		return centralDifference(f, x, derivativeStep)
+	}
+	methods := []struct {
+		name  string
+		solve func() (float64, error)
+	}{
+		{"Деление пополам", func() (float64, error) { return bisection(f, a, b, tol) }},
+		{"Метод Ньютона", func() (float64, error) { return newtonRaphson(f, df, (a+b)/2, tol, 100) }},
+		{"Метод секущих", func() (float64, error) { return secant(f, a, b, tol, 100) }},
+		{"Метод Стеффенсена", func() (float64, error) { return steffensen(f, (a+b)/2, tol, 100) }},
+		{"Метод Брента", func() (float64, error) { return brent(f, a, b, tol) }},
+	}
+
+	fmt.Printf("Корень функции на [%g, %g]:\n", a, b)
+	for _, m := range methods {
+		root, err := m.solve()
+		if err != nil {
+			fmt.Printf("  %-20s ошибка: %v\n", m.name+":", err)
+			continue
+		}
+		fmt.Printf("  %-20s x = %.12f, f(x) = %.3e\n", m.name+":", root, f(root))
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindCommand(t *testing.T) {
+	for name, handler := range map[string]func([]string) error{
+		"interp":    runInterp,
+		"integrate": runIntegrate,
+		"roots":     runRoots,
+	} {
+		cmd, err := findCommand(name)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if cmd.name != name || reflect.ValueOf(cmd.run).Pointer() != reflect.ValueOf(handler).Pointer() {
+			t.Errorf("%s: найдена подкоманда %q с другим обработчиком", name, cmd.name)
+		}
+	}
+
+	if _, err := findCommand(defaultCommand); err != nil {
+		t.Errorf("подкоманда по умолчанию %q: %v", defaultCommand, err)
+	}
+	for _, name := range []string{"", "ode", "INTERP"} {
+		if _, err := findCommand(name); err == nil {
+			t.Errorf("findCommand(%q): ожидалась ошибка", name)
+		}
+	}
+}
+
+func TestPrintCommands(t *testing.T) {
+	var out bytes.Buffer
+	printCommands(&out)
+	for _, c := range commands {
+		if !strings.Contains(out.String(), c.name) || !strings.Contains(out.String(), c.description) {
+			t.Errorf("в списке подкоманд нет %q:\n%s", c.name, out.String())
+		}
+	}
+}
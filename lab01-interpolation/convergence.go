@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// convergenceEntry содержит максимальные ошибки методов для одного количества узлов
+type convergenceEntry struct {
+	N            int
+	LagrangeErr  float64 // Лагранж на равномерных узлах
+	ChebyshevErr float64 // Лагранж на узлах Чебышева
+	SplineErr    float64 // Кубический сплайн на равномерных узлах
+}
+
+// convergenceSamples - количество точек, по которым оценивается максимальная ошибка
+const convergenceSamples = 100
+
+// maxError оценивает максимальную ошибку приближения approx функции f на [a, b] по samples точкам
+func maxError(f, approx func(float64) float64, a, b float64, samples int) float64 {
+	maxErr := 0.0
+	for _, x := range linspace(a, b, samples) {
+		err := math.Abs(f(x) - approx(x))
+		if err > maxErr {
+			maxErr = err
+		}
+	}
+	return maxErr
+}
+
+// convergenceStudy для каждого количества узлов из ns строит сетки и вычисляет
+// максимальные ошибки интерполяции на мелкой равномерной выборке. Перед каждым N проверяется
+// ctx: при отмене возвращаются уже вычисленные строки вместе с ошибкой ctx.Err()
+func convergenceStudy(ctx context.Context, a, b float64, ns []int, f func(float64) float64) ([]convergenceEntry, error) {
+	entries := make([]convergenceEntry, 0, len(ns))
+
+	for _, n := range ns {
+		if err := ctx.Err(); err != nil {
+			return entries, err
+		}
+
+		uniformData, err := createGrid(a, b, n, f)
+		if err != nil {
+			return nil, fmt.Errorf("N = %d: %w", n, err)
+		}
+		chebyshevData, err := createChebyshevGrid(a, b, n, f)
+		if err != nil {
+			return nil, fmt.Errorf("N = %d: %w", n, err)
+		}
+		spline, err := newCubicSpline(uniformData)
+		if err != nil {
+			return nil, fmt.Errorf("N = %d: %w", n, err)
+		}
+
+		entries = append(entries, convergenceEntry{
+			N: n,
+			LagrangeErr: maxError(f, func(x float64) float64 {
+				return lagrangeInterpolation(uniformData, x)
+			}, a, b, convergenceSamples),
+			ChebyshevErr: maxError(f, func(x float64) float64 {
+				return lagrangeInterpolation(chebyshevData, x)
+			}, a, b, convergenceSamples),
+			SplineErr: maxError(f, spline.evaluate, a, b, convergenceSamples),
+		})
+	}
+
+	return entries, nil
+}
+
+// convergenceOrder вычисляет эмпирический порядок сходимости p = log(errCoarse/errFine) / log(r)
+// по ошибкам на грубой и мелкой сетках, где r - отношение количества узлов (или шагов) сеток.
+// Для ошибки вида C*h^p результат равен p
+func convergenceOrder(errCoarse, errFine, refinementRatio float64) float64 {
+	return math.Log(errCoarse/errFine) / math.Log(refinementRatio)
+}
+
+// printConvergenceStudy выводит таблицу ошибок и эмпирический порядок сходимости
+// между соседними строками (см. convergenceOrder)
+func printConvergenceStudy(entries []convergenceEntry) {
+	fmt.Println("Исследование сходимости (максимальная ошибка и порядок):")
+	fmt.Printf("%-6s %-12s %-8s %-12s %-8s %-12s %-8s\n",
+		"N", "Лагр равн", "порядок", "Лагр Чеб", "порядок", "Сплайн", "порядок")
+	fmt.Println(strings.Repeat("-", 72))
+
+	for i, e := range entries {
+		orders := []string{"-", "-", "-"}
+		if i > 0 {
+			prev := entries[i-1]
+			ratio := float64(e.N) / float64(prev.N)
+			orders[0] = fmt.Sprintf("%.2f", convergenceOrder(prev.LagrangeErr, e.LagrangeErr, ratio))
+			orders[1] = fmt.Sprintf("%.2f", convergenceOrder(prev.ChebyshevErr, e.ChebyshevErr, ratio))
+			orders[2] = fmt.Sprintf("%.2f", convergenceOrder(prev.SplineErr, e.SplineErr, ratio))
+		}
+
+		fmt.Printf("%-6d %-12.4e %-8s %-12.4e %-8s %-12.4e %-8s\n",
+			e.N, e.LagrangeErr, orders[0], e.ChebyshevErr, orders[1], e.SplineErr, orders[2])
+	}
+	fmt.Println()
+}
+
+// aitkenAcceleration ускоряет сходимость последовательности Δ²-процессом Эйткена:
+// s'(n) = s(n) - (s(n+1) - s(n))^2 / (s(n+2) - 2*s(n+1) + s(n)). Результат на два элемента
+// короче исходной последовательности. Если вторая разность пренебрежимо мала (последовательность
+// уже сошлась или сходится не линейно), вместо деления берется последний из трех элементов
+func aitkenAcceleration(seq []float64) []float64 {
+	if len(seq) < 3 {
+		return nil
+	}
+
+	accelerated := make([]float64, len(seq)-2)
+	for n := range accelerated {
+		d1 := seq[n+1] - seq[n]
+		d2 := seq[n+2] - 2*seq[n+1] + seq[n]
+
+		scale := math.Max(math.Abs(seq[n]), math.Max(math.Abs(seq[n+1]), math.Abs(seq[n+2])))
+		if math.Abs(d2) <= 1e-14*scale {
+			accelerated[n] = seq[n+2]
+			continue
+		}
+		accelerated[n] = seq[n] - d1*d1/d2
+	}
+
+	return accelerated
+}
+
+// richardsonExtrapolateTable уточняет последовательность приближений A(h(0)), A(h(1)), ...
+// (значения производной, интеграла и т.п. при уменьшающемся параметре h) экстраполяцией
+// Ричардсона к пределу h -> 0. Шаги связаны отношениями ratios: h(i+1) = h(i) / ratios[i],
+// так что len(ratios) = len(values) - 1. Как в richardsonDerivative и методе Ромберга,
+// погрешность предполагается разложимой по четным степеням h: A(h) = A + c1*h^2 + c2*h^4 + ...
+// (центральная разность, формула трапеций). Значение полинома от h^2 по всем точкам при h = 0,
+// вычисленное по схеме Невилла, исключает первые len(values) - 1 членов разложения.
+// Для некорректных входных данных (пустые values, несовпадение длин, ratios <= 0 или = 1)
+// возвращается NaN
+func richardsonExtrapolateTable(values []float64, ratios []float64) float64 {
+	if len(values) == 0 || len(ratios) != len(values)-1 {
+		return math.NaN()
+	}
+
+	// Абсолютный масштаб h не влияет на значение в нуле, поэтому h(0) = 1
+	points := make([]point, len(values))
+	h := 1.0
+	for i, v := range values {
+		if i > 0 {
+			r := ratios[i-1]
+			if !(r > 0) || r == 1 {
+				return math.NaN()
+			}
+			h /= r
+		}
+		points[i] = point{x: h * h, y: v}
+	}
+
+	limit, _ := nevilleInterpolation(points, 0)
+	return limit
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestConvergenceStudyChebyshevDecreases(t *testing.T) {
+	entries, err := convergenceStudy(context.Background(), 1, 5, []int{5, 10, 20}, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("%d строк, ожидалось 3", len(entries))
+	}
+	for i := 1; i < len(entries); i++ {
+		if !(entries[i].ChebyshevErr < entries[i-1].ChebyshevErr) {
+			t.Errorf("ошибка на узлах Чебышева не уменьшается: N = %d: %.3e, N = %d: %.3e",
+				entries[i-1].N, entries[i-1].ChebyshevErr, entries[i].N, entries[i].ChebyshevErr)
+		}
+	}
+}
+
+func TestConvergenceStudyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	entries, err := convergenceStudy(ctx, 1, 5, []int{5, 10}, testFunction)
+	if !errors.Is(err, context.Canceled) || len(entries) != 0 {
+		t.Errorf("convergenceStudy после отмены = %v, %v", entries, err)
+	}
+
+	// Отмена во время вычислений для первого N: оно досчитывается, следующие пропускаются
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	cancelling := func(x float64) float64 {
+		cancel()
+		return testFunction(x)
+	}
+	entries, err = convergenceStudy(ctx, 1, 5, []int{5, 10, 20}, cancelling)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ошибка %v, ожидалась context.Canceled", err)
+	}
+	if len(entries) != 1 || entries[0].N != 5 || !(entries[0].SplineErr > 0) {
+		t.Errorf("после отмены посередине возвращено %v, ожидалась строка для N = 5", entries)
+	}
+}
+
+func TestConvergenceOrder(t *testing.T) {
+	// Ошибки C*h и C*h^4 при каждом уменьшении шага вдвое
+	for _, order := range []float64{1, 4} {
+		errs := make([]float64, 6)
+		for k := range errs {
+			errs[k] = 3 * math.Pow(0.1/math.Pow(2, float64(k)), order)
+		}
+		for k := 1; k < len(errs); k++ {
+			if p := convergenceOrder(errs[k-1], errs[k], 2); math.Abs(p-order) > 1e-12 {
+				t.Errorf("шаг %d: порядок %g, ожидалось %g", k, p, order)
+			}
+		}
+	}
+	if p := convergenceOrder(1e-2, 1e-4, 10); math.Abs(p-2) > 1e-12 {
+		t.Errorf("порядок при r = 10: %g, ожидалось 2", p)
+	}
+}
+
+func TestAitkenAcceleration(t *testing.T) {
+	// Линейная сходимость к 1 со знаменателем 0.5 и малой примесью более быстрой составляющей
+	seq := make([]float64, 10)
+	for n := range seq {
+		seq[n] = 1 + math.Pow(0.5, float64(n)) + 0.1*math.Pow(0.3, float64(n))
+	}
+
+	accelerated := aitkenAcceleration(seq)
+	if len(accelerated) != len(seq)-2 {
+		t.Fatalf("длина %d, ожидалась %d", len(accelerated), len(seq)-2)
+	}
+	for n, s := range accelerated {
+		if !(math.Abs(s-1) < 0.1*math.Abs(seq[n+2]-1)) {
+			t.Errorf("n = %d: ошибка после ускорения %.3e, исходная %.3e", n, math.Abs(s-1), math.Abs(seq[n+2]-1))
+		}
+	}
+
+	// Сошедшаяся последовательность: знаменатель нулевой, деления нет
+	for _, s := range aitkenAcceleration([]float64{2, 2, 2, 2}) {
+		if s != 2 {
+			t.Errorf("постоянная последовательность: %g, ожидалось 2", s)
+		}
+	}
+	if got := aitkenAcceleration([]float64{1, 2}); got != nil {
+		t.Errorf("короткая последовательность: %v, ожидалось nil", got)
+	}
+}
+
+func TestRichardsonExtrapolateTable(t *testing.T) {
+	// A(h) = pi + 2h^2 - 3h^4 + 0.5h^6: четыре значения исключают все три члена погрешности
+	model := func(h float64) float64 { return math.Pi + 2*h*h - 3*math.Pow(h, 4) + 0.5*math.Pow(h, 6) }
+	ratios := []float64{2, 3, 1.5}
+	values := []float64{model(0.4)}
+	h := 0.4
+	for _, r := range ratios {
+		h /= r
+		values = append(values, model(h))
+	}
+	if got := richardsonExtrapolateTable(values, ratios); math.Abs(got-math.Pi) > 1e-13 {
+		t.Errorf("предел %.16f, ожидалось %.16f", got, math.Pi)
+	}
+
+	// Центральные разности sin в точке 1 при h = 0.1, 0.05, 0.025
+	diffs := make([]float64, 3)
+	for i := range diffs {
+		diffs[i] = centralDifference(math.Sin, 1, 0.1/math.Pow(2, float64(i)))
+	}
+	got := richardsonExtrapolateTable(diffs, []float64{2, 2})
+	if err, raw := math.Abs(got-math.Cos(1)), math.Abs(diffs[2]-math.Cos(1)); !(err < 1e-10 && err < 1e-4*raw) {
+		t.Errorf("ошибка после экстраполяции %.3e, без нее %.3e", err, raw)
+	}
+
+	for _, tc := range []struct {
+		values, ratios []float64
+	}{
+		{nil, nil},
+		{[]float64{1, 2}, nil},
+		{[]float64{1, 2}, []float64{1}},
+		{[]float64{1, 2}, []float64{-2}},
+	} {
+		if got := richardsonExtrapolateTable(tc.values, tc.ratios); !math.IsNaN(got) {
+			t.Errorf("richardsonExtrapolateTable(%v, %v) = %g, ожидалось NaN", tc.values, tc.ratios, got)
+		}
+	}
+}
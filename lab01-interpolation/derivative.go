@@ -0,0 +1,58 @@
+package main
+
+// forwardDifference оценивает производную f в точке x правой разностью, погрешность O(h)
+func forwardDifference(f func(float64) float64, x, h float64) float64 {
+	return (f(x+h) - f(x)) / h
+}
+
+// centralDifference оценивает производную f в точке x центральной разностью, погрешность O(h^2)
+func centralDifference(f func(float64) float64, x, h float64) float64 {
+	return (f(x+h) - f(x-h)) / (2 * h)
+}
+
+// richardsonDerivative уточняет центральную разность экстраполяцией Ричардсона по шагам h и h/2:
+// D = (4*D(h/2) - D(h)) / 3, погрешность O(h^4)
+func richardsonDerivative(f func(float64) float64, x, h float64) float64 {
+	coarse := centralDifference(f, x, h)
+	fine := centralDifference(f, x, h/2)
+	return (4*fine - coarse) / 3
+}
+
+// nodeDerivatives оценивает производную в каждом узле таблицы по разделенным разностям:
+// дифференцируется полином Ньютона второй степени через узел и двух его соседей
+// (во внутренних узлах - центральная схема, на концах - односторонняя). Погрешность O(h^2)
+// и на неравномерной сетке. Узлы должны строго возрастать
+func nodeDerivatives(points []point) []float64 {
+	n := len(points)
+	derivatives := make([]float64, n)
+	if n < 2 {
+		return derivatives
+	}
+	if n == 2 {
+		slope := (points[1].y - points[0].y) / (points[1].x - points[0].x)
+		derivatives[0], derivatives[1] = slope, slope
+		return derivatives
+	}
+
+	for i := 0; i < n; i++ {
+		// Тройка узлов k, k+1, k+2, содержащая узел i
+		k := i - 1
+		if k < 0 {
+			k = 0
+		}
+		if k > n-3 {
+			k = n - 3
+		}
+		p0, p1, p2 := points[k], points[k+1], points[k+2]
+
+		// P(x) = f[x0] + f[x0,x1](x - x0) + f[x0,x1,x2](x - x0)(x - x1)
+		d01 := (p1.y - p0.y) / (p1.x - p0.x)
+		d12 := (p2.y - p1.y) / (p2.x - p1.x)
+		d012 := (d12 - d01) / (p2.x - p0.x)
+
+		x := points[i].x
+		derivatives[i] = d01 + d012*((x-p0.x)+(x-p1.x))
+	}
+
+	return derivatives
+}
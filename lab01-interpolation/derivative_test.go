@@ -0,0 +1,57 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFiniteDifferencesAccuracy(t *testing.T) {
+	const h = 1e-3
+	for _, x := range []float64{1, 2, 3.5, 5} {
+		want := testFunctionDerivative(x)
+		forwardErr := math.Abs(forwardDifference(testFunction, x, h) - want)
+		centralErr := math.Abs(centralDifference(testFunction, x, h) - want)
+		richardsonErr := math.Abs(richardsonDerivative(testFunction, x, h) - want)
+
+		if !(centralErr < forwardErr) {
+			t.Errorf("x = %g: ошибка центральной разности %.3e не меньше ошибки правой %.3e", x, centralErr, forwardErr)
+		}
+		if forwardErr > 1e-3 || centralErr > 1e-6 || richardsonErr > 1e-10 {
+			t.Errorf("x = %g: ошибки правой, центральной разностей и Ричардсона %.3e, %.3e, %.3e",
+				x, forwardErr, centralErr, richardsonErr)
+		}
+	}
+}
+
+func TestNodeDerivatives(t *testing.T) {
+	for _, n := range []int{10, 20, 40} {
+		data, err := createChebyshevGrid(1, 5, n, testFunction)
+		if err != nil {
+			t.Fatal(err)
+		}
+		derivatives := nodeDerivatives(data.points)
+		if len(derivatives) != len(data.points) {
+			t.Fatalf("N = %d: %d производных для %d узлов", n, len(derivatives), len(data.points))
+		}
+
+		// Погрешность O(h^2) и на неравномерной сетке: при N = 10 шаг до 0.6
+		maxErr := 0.0
+		for i, p := range data.points {
+			maxErr = math.Max(maxErr, math.Abs(derivatives[i]-testFunctionDerivative(p.x)))
+		}
+		if tol := 2.0 / float64(n*n); maxErr > tol {
+			t.Errorf("N = %d: ошибка производных в узлах %.3e больше %.3e", n, maxErr, tol)
+		}
+	}
+
+	// Для параболы формула второго порядка точна
+	parabola, err := sampleAt([]float64{0, 0.3, 1, 1.2, 2.5}, func(x float64) float64 { return 1 - x + 2*x*x })
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, d := range nodeDerivatives(parabola.points) {
+		if want := -1 + 4*parabola.points[i].x; math.Abs(d-want) > 1e-12 {
+			t.Errorf("x = %g: производная %.15g, ожидалось %.15g", parabola.points[i].x, d, want)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// exportResultsCSV записывает в CSV файл значения функции, полинома Лагранжа и кубического
+// сплайна, а также их абсолютные ошибки в numPoints равноотстоящих точках между крайними
+// узлами data (для равномерной сетки - на [a, b])
+func exportResultsCSV(filename string, data *interpolationData, testFunc func(float64) float64, numPoints int) error {
+	if numPoints < 2 {
+		return fmt.Errorf("количество точек должно быть не меньше 2, получено %d", numPoints)
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	spline, err := newCubicSpline(data)
+	if err != nil {
+		return err
+	}
+	writer := csv.NewWriter(file)
+
+	header := []string{"x", "f(x)", "lagrange", "spline", "lagrange_error", "spline_error"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	xs, splineValues := spline.sample(numPoints)
+	for i, x := range xs {
+		original := testFunc(x)
+		lagrange := lagrangeInterpolation(data, x)
+		splineVal := splineValues[i]
+
+		row := []string{
+			formatCSVFloat(x),
+			formatCSVFloat(original),
+			formatCSVFloat(lagrange),
+			formatCSVFloat(splineVal),
+			formatCSVFloat(math.Abs(original - lagrange)),
+			formatCSVFloat(math.Abs(original - splineVal)),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// formatCSVFloat форматирует число с точностью, достаточной для восстановления float64
+func formatCSVFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// resultPoint - узел интерполяции в JSON документе
+type resultPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// resultGrid - сетка узлов в JSON документе
+type resultGrid struct {
+	Name   string        `json:"name"`
+	A      float64       `json:"a"`
+	B      float64       `json:"b"`
+	N      int           `json:"n"`
+	Points []resultPoint `json:"points"`
+}
+
+// resultMethod - значения и ошибки одного метода интерполяции в точках выборки
+type resultMethod struct {
+	Name     string    `json:"name"`
+	Values   []float64 `json:"values"`
+	Errors   []float64 `json:"errors"`
+	MaxError float64   `json:"maxError"`
+	RMSError float64   `json:"rmsError"`
+	L2Error  float64   `json:"l2Error"`
+}
+
+// interpolationResults - полный набор результатов интерполяции для экспорта в JSON
+type interpolationResults struct {
+	A          float64        `json:"a"`
+	B          float64        `json:"b"`
+	Grids      []resultGrid   `json:"grids"`
+	X          []float64      `json:"x"`
+	TrueValues []float64      `json:"trueValues"`
+	Methods    []resultMethod `json:"methods"`
+}
+
+// exportResultsJSON записывает в JSON файл сетки узлов, значения методов интерполяции
+// в numPoints равноотстоящих точках [a, b] и метрики их ошибок
+func exportResultsJSON(filename string, a, b float64, grids map[string]*interpolationData, methods []Interpolator,
+	testFunc func(float64) float64, numPoints int) error {
+	if numPoints < 2 {
+		return fmt.Errorf("количество точек должно быть не меньше 2, получено %d", numPoints)
+	}
+
+	results := interpolationResults{A: a, B: b}
+
+	// Сетки выводим в порядке имен, чтобы файл не зависел от порядка обхода map
+	names := make([]string, 0, len(grids))
+	for name := range grids {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		data := grids[name]
+		grid := resultGrid{Name: name, A: data.a, B: data.b, N: data.n}
+		for _, p := range data.points {
+			grid.Points = append(grid.Points, resultPoint{X: p.x, Y: p.y})
+		}
+		results.Grids = append(results.Grids, grid)
+	}
+
+	results.X = linspace(a, b, numPoints)
+	for _, x := range results.X {
+		results.TrueValues = append(results.TrueValues, testFunc(x))
+	}
+
+	for _, m := range methods {
+		method := resultMethod{Name: m.Name()}
+		for i, x := range results.X {
+			value := m.Evaluate(x)
+			method.Values = append(method.Values, value)
+			method.Errors = append(method.Errors, math.Abs(results.TrueValues[i]-value))
+		}
+
+		// L2-норма из errorMetrics посчитана для единичного интервала, переводим на [a, b]
+		maxErr, rms, l2 := errorMetrics(method.Errors)
+		method.MaxError = maxErr
+		method.RMSError = rms
+		method.L2Error = l2 * math.Sqrt(b-a)
+
+		results.Methods = append(results.Methods, method)
+	}
+
+	content, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, content, 0644)
+}
+
+// exportGnuplot записывает для gnuplot значения функции, полинома Лагранжа и кубического
+// сплайна, а также их абсолютные ошибки в numPoints равноотстоящих точках между крайними
+// узлами data: каждый ряд - в отдельный файл prefix_<ряд>.dat из двух столбцов (x и значение),
+// и скрипт prefix.gp, строящий по ним графики значений и ошибок (запуск: gnuplot -p prefix.gp)
+func exportGnuplot(prefix string, data *interpolationData, testFunc func(float64) float64, numPoints int) error {
+	if numPoints < 2 {
+		return fmt.Errorf("количество точек должно быть не меньше 2, получено %d", numPoints)
+	}
+
+	spline, err := newCubicSpline(data)
+	if err != nil {
+		return err
+	}
+
+	series := []struct {
+		name, title string
+		isError     bool // Ряд выводится на графике ошибок
+		values      []float64
+	}{
+		{name: "function", title: "f(x)"},
+		{name: "lagrange", title: "Лагранж"},
+		{name: "spline", title: "Кубический сплайн"},
+		{name: "lagrange_error", title: "Ошибка Лагранжа", isError: true},
+		{name: "spline_error", title: "Ошибка сплайна", isError: true},
+	}
+	xs, splineValues := spline.sample(numPoints)
+	for i, x := range xs {
+		original := testFunc(x)
+		lagrange := lagrangeInterpolation(data, x)
+		splineVal := splineValues[i]
+
+		for k, v := range []float64{original, lagrange, splineVal, math.Abs(original - lagrange), math.Abs(original - splineVal)} {
+			series[k].values = append(series[k].values, v)
+		}
+	}
+
+	// plot-команды для графика значений и графика ошибок
+	var valuesPlot, errorsPlot []string
+	for _, s := range series {
+		filename := prefix + "_" + s.name + ".dat"
+		if err := writeGnuplotData(filename, xs, s.values); err != nil {
+			return err
+		}
+
+		entry := fmt.Sprintf("'%s' with lines title '%s'", gnuplotQuote(filename), gnuplotQuote(s.title))
+		if s.isError {
+			errorsPlot = append(errorsPlot, entry)
+		} else {
+			valuesPlot = append(valuesPlot, entry)
+		}
+	}
+
+	script := fmt.Sprintf(`set multiplot layout 2,1
+set xlabel 'x'
+set title 'Результаты интерполяции (N = %d узлов)'
+plot %s
+set title 'Абсолютные ошибки интерполяции'
+set logscale y
+plot %s
+unset multiplot
+`, data.n, strings.Join(valuesPlot, ", \\\n     "), strings.Join(errorsPlot, ", \\\n     "))
+
+	return os.WriteFile(prefix+".gp", []byte(script), 0644)
+}
+
+// writeGnuplotData записывает ряд в файл данных gnuplot: по строке "x значение" на точку
+func writeGnuplotData(filename string, xs, values []float64) error {
+	var content strings.Builder
+	for i, x := range xs {
+		fmt.Fprintf(&content, "%s %s\n", formatCSVFloat(x), formatCSVFloat(values[i]))
+	}
+	return os.WriteFile(filename, []byte(content.String()), 0644)
+}
+
+// gnuplotQuote экранирует строку для вставки в одинарные кавычки скрипта gnuplot
+func gnuplotQuote(s string) string {
+	return strings.ReplaceAll(s, "'", "''")
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// readCSV читает все записи CSV-файла
+func readCSV(t *testing.T, filename string) [][]string {
+	t.Helper()
+	file, err := os.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return records
+}
+
+func TestExportResultsCSV(t *testing.T) {
+	data, err := createGrid(1, 5, 10, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filename := filepath.Join(t.TempDir(), "results.csv")
+	const numPoints = 57
+	if err := exportResultsCSV(filename, data, testFunction, numPoints); err != nil {
+		t.Fatal(err)
+	}
+
+	records := readCSV(t, filename)
+	if len(records) != numPoints+1 {
+		t.Fatalf("%d строк, ожидалось %d", len(records), numPoints+1)
+	}
+	header := []string{"x", "f(x)", "lagrange", "spline", "lagrange_error", "spline_error"}
+	if !slices.Equal(records[0], header) {
+		t.Errorf("заголовок %v, ожидался %v", records[0], header)
+	}
+
+	// Значения записаны без потери точности: f(x) восстанавливается точно
+	for _, row := range records[1:] {
+		x, err := strconv.ParseFloat(row[0], 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fx, err := strconv.ParseFloat(row[1], 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(fx-testFunction(x)) > 1e-12 {
+			t.Errorf("x = %s: f(x) = %s, ожидалось %.17g", row[0], row[1], testFunction(x))
+		}
+	}
+
+	if err := exportResultsCSV(filename, data, testFunction, 1); err == nil {
+		t.Error("ожидалась ошибка для numPoints = 1")
+	}
+}
+
+func TestExportResultsJSONRoundTrip(t *testing.T) {
+	uniform, err := createGrid(1, 5, 6, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	methods := testInterpolators(t, 6)
+	filename := filepath.Join(t.TempDir(), "results.json")
+	const numPoints = 31
+	if err := exportResultsJSON(filename, 1, 5, map[string]*interpolationData{"равномерная": uniform},
+		methods, testFunction, numPoints); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var results interpolationResults
+	if err := json.Unmarshal(content, &results); err != nil {
+		t.Fatal(err)
+	}
+
+	if results.A != 1 || results.B != 5 || len(results.X) != numPoints || len(results.TrueValues) != numPoints {
+		t.Fatalf("a = %g, b = %g, %d точек, %d значений функции", results.A, results.B, len(results.X), len(results.TrueValues))
+	}
+	if len(results.Grids) != 1 || results.Grids[0].N != 6 || len(results.Grids[0].Points) != 7 {
+		t.Fatalf("сетки %+v", results.Grids)
+	}
+	for i, p := range results.Grids[0].Points {
+		if p.X != uniform.points[i].x || p.Y != uniform.points[i].y {
+			t.Errorf("узел %d: (%g, %g), ожидалось (%g, %g)", i, p.X, p.Y, uniform.points[i].x, uniform.points[i].y)
+		}
+	}
+
+	if len(results.Methods) != len(methods) {
+		t.Fatalf("%d методов, ожидалось %d", len(results.Methods), len(methods))
+	}
+	for k, m := range methods {
+		rm := results.Methods[k]
+		if rm.Name != m.Name() || len(rm.Values) != numPoints {
+			t.Fatalf("метод %d: %q, %d значений", k, rm.Name, len(rm.Values))
+		}
+		maxErr := 0.0
+		for i, x := range results.X {
+			if rm.Values[i] != m.Evaluate(x) {
+				t.Errorf("%s(%g) = %g, в файле %g", m.Name(), x, m.Evaluate(x), rm.Values[i])
+			}
+			maxErr = math.Max(maxErr, math.Abs(testFunction(x)-m.Evaluate(x)))
+		}
+		if rm.MaxError != maxErr {
+			t.Errorf("%s: максимальная ошибка %g, в файле %g", m.Name(), maxErr, rm.MaxError)
+		}
+	}
+}
+
+func TestExportGnuplot(t *testing.T) {
+	data, err := createGrid(1, 5, 8, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const numPoints = 37
+	prefix := filepath.Join(t.TempDir(), "interp")
+	if err := exportGnuplot(prefix, data, testFunction, numPoints); err != nil {
+		t.Fatal(err)
+	}
+
+	script, err := os.ReadFile(prefix + ".gp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"function", "lagrange", "spline", "lagrange_error", "spline_error"} {
+		filename := prefix + "_" + name + ".dat"
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+
+		lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+		if len(lines) != numPoints {
+			t.Errorf("%s: %d строк, ожидалось %d", name, len(lines), numPoints)
+		}
+		for _, line := range lines {
+			fields := strings.Fields(line)
+			if len(fields) != 2 {
+				t.Errorf("%s: строка %q, ожидалось два столбца", name, line)
+				break
+			}
+			for _, field := range fields {
+				if _, err := strconv.ParseFloat(field, 64); err != nil {
+					t.Errorf("%s: %v", name, err)
+				}
+			}
+		}
+
+		if !strings.Contains(string(script), "'"+filename+"'") {
+			t.Errorf("скрипт не строит ряд %s", filename)
+		}
+	}
+
+	if err := exportGnuplot(prefix, data, testFunction, 1); err == nil {
+		t.Error("ожидалась ошибка для одной точки")
+	}
+}
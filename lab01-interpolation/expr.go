@@ -0,0 +1,274 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"unicode"
+)
+
+// exprFunctions содержит элементарные функции, доступные в выражениях
+var exprFunctions = map[string]func(float64) float64{
+	"sin":   math.Sin,
+	"cos":   math.Cos,
+	"tan":   math.Tan,
+	"asin":  math.Asin,
+	"acos":  math.Acos,
+	"atan":  math.Atan,
+	"sinh":  math.Sinh,
+	"cosh":  math.Cosh,
+	"tanh":  math.Tanh,
+	"exp":   math.Exp,
+	"log":   math.Log,
+	"ln":    math.Log,
+	"log10": math.Log10,
+	"log2":  math.Log2,
+	"sqrt":  math.Sqrt,
+	"abs":   math.Abs,
+}
+
+// exprConstants содержит именованные константы, доступные в выражениях
+var exprConstants = map[string]float64{
+	"pi": math.Pi,
+	"e":  math.E,
+}
+
+// exprParser - рекурсивный нисходящий разборщик арифметических выражений от x.
+// Грамматика:
+//
+//	expr    = term {("+" | "-") term}
+//	term    = unary {("*" | "/") unary}
+//	unary   = ("+" | "-") unary | power
+//	power   = primary ["^" unary]
+//	primary = number | "x" | constant | function "(" expr ")" | "(" expr ")"
+type exprParser struct {
+	input []rune
+	pos   int
+}
+
+// parseFunction разбирает выражение от x (операции + - * / ^, скобки, элементарные функции
+// и константы pi, e) и возвращает функцию, вычисляющую его значение
+func parseFunction(expr string) (func(float64) float64, error) {
+	p := &exprParser{input: []rune(expr)}
+
+	f, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return nil, p.errorf("неожиданный символ %q", p.input[p.pos])
+	}
+
+	return f, nil
+}
+
+// errorf формирует ошибку разбора с указанием позиции
+func (p *exprParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("ошибка в выражении на позиции %d: %s", p.pos+1, fmt.Sprintf(format, args...))
+}
+
+// skipSpaces пропускает пробельные символы
+func (p *exprParser) skipSpaces() {
+	for p.pos < len(p.input) && unicode.IsSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+// peek возвращает следующий значимый символ или 0 в конце строки
+func (p *exprParser) peek() rune {
+	p.skipSpaces()
+	if p.pos >= len(p.input) {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+// parseExpr разбирает сумму и разность слагаемых
+func (p *exprParser) parseExpr() (func(float64) float64, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op := p.peek()
+		if op != '+' && op != '-' {
+			return left, nil
+		}
+		p.pos++
+
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+
+		l := left
+		if op == '+' {
+			left = func(x float64) float64 { return l(x) + right(x) }
+		} else {
+			left = func(x float64) float64 { return l(x) - right(x) }
+		}
+	}
+}
+
+// parseTerm разбирает произведение и частное множителей
+func (p *exprParser) parseTerm() (func(float64) float64, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op := p.peek()
+		if op != '*' && op != '/' {
+			return left, nil
+		}
+		p.pos++
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		l := left
+		if op == '*' {
+			left = func(x float64) float64 { return l(x) * right(x) }
+		} else {
+			left = func(x float64) float64 { return l(x) / right(x) }
+		}
+	}
+}
+
+// parseUnary разбирает унарные плюс и минус
+func (p *exprParser) parseUnary() (func(float64) float64, error) {
+	switch p.peek() {
+	case '-':
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(x float64) float64 { return -operand(x) }, nil
+	case '+':
+		p.pos++
+		return p.parseUnary()
+	}
+	return p.parsePower()
+}
+
+// parsePower разбирает возведение в степень (правоассоциативное)
+func (p *exprParser) parsePower() (func(float64) float64, error) {
+	base, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek() != '^' {
+		return base, nil
+	}
+	p.pos++
+
+	exponent, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return func(x float64) float64 { return math.Pow(base(x), exponent(x)) }, nil
+}
+
+// parsePrimary разбирает число, переменную, константу, вызов функции или выражение в скобках
+func (p *exprParser) parsePrimary() (func(float64) float64, error) {
+	c := p.peek()
+
+	switch {
+	case c == 0:
+		return nil, p.errorf("неожиданный конец выражения")
+
+	case c == '(':
+		p.pos++
+		inner, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, p.errorf("ожидается ')'")
+		}
+		p.pos++
+		return inner, nil
+
+	case unicode.IsDigit(c) || c == '.':
+		return p.parseNumber()
+
+	case unicode.IsLetter(c):
+		return p.parseIdentifier()
+	}
+
+	return nil, p.errorf("неожиданный символ %q", c)
+}
+
+// parseNumber разбирает числовую константу, в том числе в экспоненциальной записи
+func (p *exprParser) parseNumber() (func(float64) float64, error) {
+	start := p.pos
+	for p.pos < len(p.input) && (unicode.IsDigit(p.input[p.pos]) || p.input[p.pos] == '.') {
+		p.pos++
+	}
+	// Экспонента: 1e-3, 2.5E+4
+	if p.pos < len(p.input) && (p.input[p.pos] == 'e' || p.input[p.pos] == 'E') {
+		next := p.pos + 1
+		if next < len(p.input) && (p.input[next] == '+' || p.input[next] == '-') {
+			next++
+		}
+		if next < len(p.input) && unicode.IsDigit(p.input[next]) {
+			p.pos = next
+			for p.pos < len(p.input) && unicode.IsDigit(p.input[p.pos]) {
+				p.pos++
+			}
+		}
+	}
+
+	text := string(p.input[start:p.pos])
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		p.pos = start
+		return nil, p.errorf("некорректное число %q", text)
+	}
+	return func(float64) float64 { return value }, nil
+}
+
+// parseIdentifier разбирает переменную x, константу или вызов функции
+func (p *exprParser) parseIdentifier() (func(float64) float64, error) {
+	start := p.pos
+	for p.pos < len(p.input) && (unicode.IsLetter(p.input[p.pos]) || unicode.IsDigit(p.input[p.pos])) {
+		p.pos++
+	}
+	name := string(p.input[start:p.pos])
+
+	if name == "x" {
+		return func(x float64) float64 { return x }, nil
+	}
+	if value, ok := exprConstants[name]; ok {
+		return func(float64) float64 { return value }, nil
+	}
+
+	fn, ok := exprFunctions[name]
+	if !ok {
+		p.pos = start
+		return nil, p.errorf("неизвестный идентификатор %q", name)
+	}
+
+	if p.peek() != '(' {
+		return nil, p.errorf("ожидается '(' после имени функции %s", name)
+	}
+	p.pos++
+	arg, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek() != ')' {
+		return nil, p.errorf("ожидается ')' после аргумента функции %s", name)
+	}
+	p.pos++
+
+	return func(x float64) float64 { return fn(arg(x)) }, nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseFunctionMatchesTestFunction(t *testing.T) {
+	f, err := parseFunction("x*log10(x+1)-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := createGrid(1, 5, 20, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range data.points {
+		if got := f(p.x); math.Abs(got-p.y) > 1e-15 {
+			t.Errorf("f(%g) = %.17g, testFunction = %.17g", p.x, got, p.y)
+		}
+	}
+}
+
+func TestParseFunctionPrecedence(t *testing.T) {
+	const x = 1.5
+	for _, tc := range []struct {
+		expr string
+		want float64
+	}{
+		{"1 + 2*x", 4},
+		{"(1 + 2)*x", 4.5},
+		{"2^3^2", 512},
+		{"-x^2", -2.25},
+		{"8 / 4 / 2", 1},
+		{"1 - x - 1", -1.5},
+		{"abs(-x) + sin(0) + cos(pi)", 0.5},
+		{"2.5e-1 * x", 0.375},
+		{"exp(log(x))", 1.5},
+	} {
+		f, err := parseFunction(tc.expr)
+		if err != nil {
+			t.Errorf("%q: %v", tc.expr, err)
+			continue
+		}
+		if got := f(x); math.Abs(got-tc.want) > 1e-14 {
+			t.Errorf("%q при x = %g: %g, ожидалось %g", tc.expr, x, got, tc.want)
+		}
+	}
+}
+
+func TestParseFunctionErrors(t *testing.T) {
+	for _, expr := range []string{"", "x +", "(x", "x)", "2 * * x", "foo(x)", "sin x", "y", "1..2", "x # 1"} {
+		if _, err := parseFunction(expr); err == nil {
+			t.Errorf("%q: ожидалась ошибка разбора", expr)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import "math"
+
+// hermiteSpline представляет кубический сплайн Эрмита, заданный значениями и наклонами в узлах.
+// В отличие от cubicSpline вторая производная такого сплайна в узлах может терпеть разрыв
+type hermiteSpline struct {
+	points []point
+	slopes []float64 // Первые производные сплайна в узлах
+}
+
+// newPCHIP создает монотонный кубический сплайн Эрмита (PCHIP) с оценками производных
+// по методу Фрича-Карлсона. На участках монотонности данных сплайн не дает выбросов
+func newPCHIP(data *interpolationData) (*hermiteSpline, error) {
+	points := data.points
+	if err := validateSplinePoints(points); err != nil {
+		return nil, err
+	}
+	n := len(points)
+
+	// Длины отрезков и наклоны секущих
+	h := make([]float64, n-1)
+	delta := make([]float64, n-1)
+	for i := 0; i < n-1; i++ {
+		h[i] = points[i+1].x - points[i].x
+		delta[i] = (points[i+1].y - points[i].y) / h[i]
+	}
+
+	slopes := make([]float64, n)
+	if n == 2 {
+		slopes[0] = delta[0]
+		slopes[1] = delta[0]
+		return &hermiteSpline{points: points, slopes: slopes}, nil
+	}
+
+	// Внутренние узлы: взвешенное гармоническое среднее соседних наклонов,
+	// ноль в локальных экстремумах данных
+	for i := 1; i < n-1; i++ {
+		if delta[i-1]*delta[i] <= 0 {
+			slopes[i] = 0
+			continue
+		}
+		w1 := 2*h[i] + h[i-1]
+		w2 := h[i] + 2*h[i-1]
+		slopes[i] = (w1 + w2) / (w1/delta[i-1] + w2/delta[i])
+	}
+
+	// Концевые узлы: трехточечная формула с поправкой, сохраняющей монотонность
+	slopes[0] = pchipEndSlope(h[0], h[1], delta[0], delta[1])
+	slopes[n-1] = pchipEndSlope(h[n-2], h[n-3], delta[n-2], delta[n-3])
+
+	return &hermiteSpline{points: points, slopes: slopes}, nil
+}
+
+// pchipEndSlope вычисляет наклон в концевом узле по ближнему (h0, d0) и следующему (h1, d1) отрезкам
+func pchipEndSlope(h0, h1, d0, d1 float64) float64 {
+	slope := ((2*h0+h1)*d0 - h0*d1) / (h0 + h1)
+	if math.Signbit(slope) != math.Signbit(d0) || d0 == 0 {
+		return 0
+	}
+	if math.Signbit(d0) != math.Signbit(d1) && math.Abs(slope) > 3*math.Abs(d0) {
+		return 3 * d0
+	}
+	return slope
+}
+
+// evaluate вычисляет значение сплайна Эрмита в точке x через базисные полиномы Эрмита
+func (hs *hermiteSpline) evaluate(x float64) float64 {
+	// Находим интервал, содержащий точку x
+	i := findInterval(hs.points, x)
+
+	xi := hs.points[i].x
+	hi := hs.points[i+1].x - xi
+	t := (x - xi) / hi
+	t2 := t * t
+	t3 := t2 * t
+
+	h00 := 2*t3 - 3*t2 + 1
+	h10 := t3 - 2*t2 + t
+	h01 := -2*t3 + 3*t2
+	h11 := t3 - t2
+
+	return h00*hs.points[i].y + h10*hi*hs.slopes[i] + h01*hs.points[i+1].y + h11*hi*hs.slopes[i+1]
+}
+
+// newAkimaSpline создает сплайн Акимы: наклон в узле - взвешенное среднее наклонов соседних
+// отрезков, веса которого гасят влияние выбросов. Недостающие наклоны за концами интервала
+// получаются линейной экстраполяцией
+func newAkimaSpline(data *interpolationData) (*hermiteSpline, error) {
+	points := data.points
+	if err := validateSplinePoints(points); err != nil {
+		return nil, err
+	}
+	n := len(points)
+
+	// m[k+2] - наклон отрезка k; по два дополнительных наклона с каждой стороны
+	m := make([]float64, n+3)
+	for k := 0; k < n-1; k++ {
+		m[k+2] = (points[k+1].y - points[k].y) / (points[k+1].x - points[k].x)
+	}
+
+	slopes := make([]float64, n)
+	if n == 2 {
+		slopes[0] = m[2]
+		slopes[1] = m[2]
+		return &hermiteSpline{points: points, slopes: slopes}, nil
+	}
+
+	m[1] = 2*m[2] - m[3]
+	m[0] = 2*m[1] - m[2]
+	m[n+1] = 2*m[n] - m[n-1]
+	m[n+2] = 2*m[n+1] - m[n]
+
+	for i := 0; i < n; i++ {
+		// Для узла i используются наклоны m(i-2), m(i-1), m(i), m(i+1)
+		w1 := math.Abs(m[i+3] - m[i+2])
+		w2 := math.Abs(m[i+1] - m[i])
+		if w1+w2 < 1e-12 {
+			slopes[i] = (m[i+1] + m[i+2]) / 2
+		} else {
+			slopes[i] = (w1*m[i+1] + w2*m[i+2]) / (w1 + w2)
+		}
+	}
+
+	return &hermiteSpline{points: points, slopes: slopes}, nil
+}
+
+// domain возвращает отрезок между крайними узлами сплайна
+func (hs *hermiteSpline) domain() (lo, hi float64) {
+	return nodeRange(hs.points)
+}
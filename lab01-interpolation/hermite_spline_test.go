@@ -0,0 +1,101 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// hermiteTestSamples - количество проверяемых точек на каждом отрезке между узлами
+const hermiteTestSamples = 50
+
+// maxOvershoot возвращает наибольший выход значений eval за пределы значений в концах
+// отрезков между соседними узлами
+func maxOvershoot(points []point, eval func(float64) float64) float64 {
+	overshoot := 0.0
+	for i := 0; i < len(points)-1; i++ {
+		lo := math.Min(points[i].y, points[i+1].y)
+		hi := math.Max(points[i].y, points[i+1].y)
+		for _, x := range linspace(points[i].x, points[i+1].x, hermiteTestSamples) {
+			y := eval(x)
+			overshoot = math.Max(overshoot, math.Max(lo-y, y-hi))
+		}
+	}
+	return overshoot
+}
+
+func TestPCHIPNoOvershoot(t *testing.T) {
+	module, err := createGrid(-1, 1, 7, moduleFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	step, err := sampleAt([]float64{0, 1, 2, 3, 4, 5, 6, 7}, func(x float64) float64 {
+		return math.Round(math.Max(0, math.Min(1, (x-2.5)/2)) * 4)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		data *interpolationData
+	}{
+		{"модуль", module},
+		{"ступенька", step},
+	} {
+		pchip, err := newPCHIP(tc.data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if o := maxOvershoot(tc.data.points, pchip.evaluate); o > 1e-12 {
+			t.Errorf("%s: PCHIP выходит за значения в узлах на %.3e", tc.name, o)
+		}
+
+		// Кубический сплайн на тех же данных выбросы дает - иначе тест ничего не проверяет
+		spline, err := newCubicSpline(tc.data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if o := maxOvershoot(tc.data.points, spline.evaluate); o < 1e-3 {
+			t.Errorf("%s: кубический сплайн неожиданно не дает выбросов (%.3e)", tc.name, o)
+		}
+	}
+}
+
+func TestAkimaSuppressesRinging(t *testing.T) {
+	// Ровные данные с одним выбросом в x = 5
+	xs := linspace(0, 10, 11)
+	data, err := sampleAt(xs, func(x float64) float64 {
+		if x == 5 {
+			return 1
+		}
+		return 0
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	akima, err := newAkimaSpline(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spline, err := newCubicSpline(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Колебания вдали от выброса: на [0, 3] и [7, 10] данные равны нулю
+	ringing := func(eval func(float64) float64) float64 {
+		r := 0.0
+		for _, x := range append(linspace(0, 3, 3*hermiteTestSamples), linspace(7, 10, 3*hermiteTestSamples)...) {
+			r = math.Max(r, math.Abs(eval(x)))
+		}
+		return r
+	}
+	akimaRinging, splineRinging := ringing(akima.evaluate), ringing(spline.evaluate)
+	if !(akimaRinging < splineRinging/10) {
+		t.Errorf("колебания сплайна Акимы %.3e не намного меньше колебаний кубического сплайна %.3e",
+			akimaRinging, splineRinging)
+	}
+	if o, so := maxOvershoot(data.points, akima.evaluate), maxOvershoot(data.points, spline.evaluate); !(o < so) {
+		t.Errorf("выброс сплайна Акимы %.3e не меньше выброса кубического сплайна %.3e", o, so)
+	}
+}
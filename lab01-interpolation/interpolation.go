@@ -0,0 +1,324 @@
+package main
+
+import "math"
+
+// hermitePoint представляет узел интерполяции Эрмита: значение и производная в точке x
+type hermitePoint struct {
+	x, y, dy float64
+}
+
+// hermiteInterpolation вычисляет значение интерполяционного полинома Эрмита в точке x
+// через разделенные разности с кратными узлами. Узел с неизвестной производной
+// (dy = NaN) используется однократно, поэтому при всех dy = NaN получается полином Лагранжа
+func hermiteInterpolation(points []hermitePoint, x float64) float64 {
+	// Строим последовательность узлов z: узел с известной производной повторяется дважды
+	var z, q, dz []float64
+	for _, p := range points {
+		z = append(z, p.x)
+		q = append(q, p.y)
+		dz = append(dz, math.NaN())
+		if !math.IsNaN(p.dy) {
+			z = append(z, p.x)
+			q = append(q, p.y)
+			dz = append(dz, p.dy)
+		}
+	}
+	m := len(z)
+
+	// Разделенные разности считаем на месте: после шага j в q[i] лежит f[z(i-j), ..., z(i)]
+	coeffs := make([]float64, m)
+	coeffs[0] = q[0]
+	for j := 1; j < m; j++ {
+		for i := m - 1; i >= j; i-- {
+			if j == 1 && z[i] == z[i-1] {
+				// Для кратного узла первая разделенная разность равна производной
+				q[i] = dz[i]
+			} else {
+				q[i] = (q[i] - q[i-1]) / (z[i] - z[i-j])
+			}
+		}
+		coeffs[j] = q[j]
+	}
+
+	// Вычисляем полином в форме Ньютона по схеме Горнера
+	result := coeffs[m-1]
+	for i := m - 2; i >= 0; i-- {
+		result = result*(x-z[i]) + coeffs[i]
+	}
+
+	return result
+}
+
+// nevilleInterpolation вычисляет значение интерполяционного полинома в точке x по схеме Невилла.
+// Вторым значением возвращается оценка погрешности - модуль последней поправки в таблице,
+// т.е. насколько изменилось значение при добавлении последнего узла. Для пустого набора узлов
+// возвращает NaN, NaN
+func nevilleInterpolation(points []point, x float64) (float64, float64) {
+	n := len(points)
+	if n == 0 {
+		return math.NaN(), math.NaN()
+	}
+
+	// Строим таблицу по строкам: row[j] - значение полинома по узлам i-j..i
+	var prev, row []float64
+	for i := 0; i < n; i++ {
+		xi := points[i].x
+		row = make([]float64, i+1)
+		row[0] = points[i].y
+		for j := 1; j <= i; j++ {
+			xij := points[i-j].x
+			row[j] = ((x-xij)*row[j-1] - (x-xi)*prev[j-1]) / (xi - xij)
+		}
+		if i < n-1 {
+			prev = row
+		}
+	}
+
+	if n < 2 {
+		return row[0], 0
+	}
+	return row[n-1], math.Abs(row[n-1] - prev[n-2])
+}
+
+// rationalTiny - малая добавка к d в rationalInterpolation, исключающая деление 0/0,
+// когда интерполируемая функция обращается в ноль в узле
+const rationalTiny = 1e-25
+
+// rationalInterpolation вычисляет в точке x значение диагональной рациональной функции P(x)/Q(x),
+// проходящей через все точки, по рекуррентной схеме Булирша-Штёра (аналог схемы Невилла).
+// В отличие от полинома хорошо приближает функции с полюсами и функции вида 1 / (1 + 25x^2).
+// Вторым значением возвращается оценка погрешности - последняя поправка. Если на каком-то
+// шаге рекурсии знаменатель обращается в ноль (у рациональной функции полюс в точке x),
+// возвращается приближение, накопленное до этого шага, и погрешность +Inf
+func rationalInterpolation(points []point, x float64) (float64, float64) {
+	n := len(points)
+	if n == 0 {
+		return math.NaN(), math.NaN()
+	}
+
+	// Начинаем с ближайшего к x узла; c и d - поправки, как в схеме Невилла
+	c := make([]float64, n)
+	d := make([]float64, n)
+	ns := 0
+	closest := math.Abs(x - points[0].x)
+	for i, p := range points {
+		dist := math.Abs(x - p.x)
+		if dist == 0 {
+			return p.y, 0
+		}
+		if dist < closest {
+			ns, closest = i, dist
+		}
+		c[i] = p.y
+		d[i] = p.y + rationalTiny
+	}
+
+	y := points[ns].y
+	ns--
+	dy := 0.0
+	for m := 1; m < n; m++ {
+		for i := 0; i < n-m; i++ {
+			w := c[i+1] - d[i]
+			h := points[i+m].x - x
+			t := (points[i].x - x) * d[i] / h
+			denom := t - c[i+1]
+			if denom == 0 {
+				return y, math.Inf(1)
+			}
+			denom = w / denom
+			d[i] = c[i+1] * denom
+			c[i] = t * denom
+		}
+
+		// Идем по таблице к вершине путем, ближайшим к прямой линии через x
+		if 2*(ns+1) < n-m {
+			dy = c[ns+1]
+		} else {
+			dy = d[ns]
+			ns--
+		}
+		y += dy
+	}
+
+	return y, math.Abs(dy)
+}
+
+// lagrangeErrorBound оценивает сверху погрешность интерполяции Лагранжа в точке x:
+// |f(x) - L(x)| <= M/(n+1)! * |(x - x0)(x - x1)...(x - xn)|, где derivBound = M - оценка
+// max|f^(n+1)| на интервале, а n+1 - количество узлов
+func lagrangeErrorBound(data *interpolationData, derivBound float64, x float64) float64 {
+	nodes := make([]float64, len(data.points))
+	for i, p := range data.points {
+		nodes[i] = p.x
+	}
+
+	// (n+1)! переполняется уже при 171 узле, поэтому делим на него через логарифм гамма-функции
+	logFactorial, _ := math.Lgamma(float64(len(nodes) + 1))
+	return derivBound * math.Abs(nodePolynomial(nodes, x)) * math.Exp(-logFactorial)
+}
+
+// nodePolynomial вычисляет узловой полином omega(x) = (x - x0)(x - x1)...(x - xn). Он обращается
+// в ноль в узлах и определяет форму погрешности интерполяции Лагранжа между ними: у узлов
+// Чебышева max|omega| на [a, b] минимален
+func nodePolynomial(nodes []float64, x float64) float64 {
+	result := 1.0
+	for _, xi := range nodes {
+		result *= x - xi
+	}
+	return result
+}
+
+// linearInterpolation вычисляет значение кусочно-линейного интерполянта в точке x.
+// Узлы должны быть упорядочены по возрастанию x
+func linearInterpolation(data *interpolationData, x float64) float64 {
+	switch len(data.points) {
+	case 0:
+		return math.NaN()
+	case 1:
+		return data.points[0].y
+	}
+
+	i := findInterval(data.points, x)
+	p0, p1 := data.points[i], data.points[i+1]
+	return p0.y + (p1.y-p0.y)*(x-p0.x)/(p1.x-p0.x)
+}
+
+// barycentricWeights вычисляет барицентрические веса w(i) = 1 / prod(x(i) - x(j), j != i)
+// за O(n^2). После этого значение полинома Лагранжа в любой точке вычисляется за O(n)
+func barycentricWeights(points []point) []float64 {
+	weights := make([]float64, len(points))
+	for i := range points {
+		w := 1.0
+		for j := range points {
+			if i != j {
+				w *= points[i].x - points[j].x
+			}
+		}
+		weights[i] = 1 / w
+	}
+	return weights
+}
+
+// barycentricInterpolation вычисляет значение полинома Лагранжа в точке x по первой
+// барицентрической формуле L(x) = l(x) * sum(w(i)*y(i)/(x - x(i))), l(x) = prod(x - x(j)),
+// с заранее вычисленными весами. Формула обратно устойчива для любых узлов; в узле
+// возвращается точное значение y
+func barycentricInterpolation(points []point, weights []float64, x float64) float64 {
+	l := 1.0
+	sum := 0.0
+	for i, p := range points {
+		diff := x - p.x
+		if diff == 0 {
+			return p.y
+		}
+		l *= diff
+		sum += weights[i] * p.y / diff
+	}
+	return l * sum
+}
+
+// floaterHormann вычисляет в точке x значение рациональной интерполяции Флоатера-Хормана
+// с параметром смешивания d: это взвешенное среднее полиномов степени d по всем наборам из
+// d+1 подряд идущих узлов, записанное во второй барицентрической форме. У интерполянта нет
+// полюсов на отрезке, а при небольших d он не осциллирует даже на равномерных узлах
+// (функция Рунге). Узлы должны быть упорядочены по возрастанию x; d приводится к [0, n],
+// где n+1 - количество узлов, при d = n получается полином Лагранжа. В узле возвращается
+// точное значение y
+func floaterHormann(points []point, d int, x float64) float64 {
+	n := len(points) - 1
+	if n < 0 {
+		return math.NaN()
+	}
+	d = max(0, min(d, n))
+
+	num, den := 0.0, 0.0
+	for k, p := range points {
+		diff := x - p.x
+		if diff == 0 {
+			return p.y
+		}
+
+		// w(k) = (-1)^(k-d) * sum по i из [max(0, k-d), min(k, n-d)] произведений 1/|x(k) - x(j)|,
+		// j = i..i+d, j != k
+		w := 0.0
+		for i := max(0, k-d); i <= min(k, n-d); i++ {
+			prod := 1.0
+			for j := i; j <= i+d; j++ {
+				if j != k {
+					prod /= math.Abs(p.x - points[j].x)
+				}
+			}
+			w += prod
+		}
+		if (k-d)%2 != 0 {
+			w = -w
+		}
+
+		num += w * p.y / diff
+		den += w / diff
+	}
+	return num / den
+}
+
+// vecPoint представляет узел интерполяции векторной функции R -> R^k
+type vecPoint struct {
+	x float64
+	y []float64
+}
+
+// lagrangeInterpolationVec вычисляет значение интерполяционного полинома Лагранжа векторной
+// функции в точке x. Базисные полиномы Li(x) вычисляются один раз для всех k компонент
+func lagrangeInterpolationVec(points []vecPoint, x float64) []float64 {
+	if len(points) == 0 {
+		return nil
+	}
+	result := make([]float64, len(points[0].y))
+
+	for i := range points {
+		li := 1.0
+		for j := range points {
+			if i != j {
+				li *= (x - points[j].x) / (points[i].x - points[j].x)
+			}
+		}
+		for k, yk := range points[i].y {
+			result[k] += yk * li
+		}
+	}
+
+	return result
+}
+
+// lebesgueConstant оценивает константу Лебега набора узлов - максимум функции Лебега
+// sum|Li(x)| на отрезке между крайними узлами по samplePoints равноотстоящим точкам.
+// Константа ограничивает рост ошибки интерполяции: |f - Ln f| <= (1 + Λ) * E(наилучшего приближения)
+func lebesgueConstant(nodes []float64, samplePoints int) float64 {
+	if len(nodes) == 0 || samplePoints < 2 {
+		return 0
+	}
+
+	lo, hi := nodes[0], nodes[0]
+	for _, x := range nodes {
+		lo = math.Min(lo, x)
+		hi = math.Max(hi, x)
+	}
+
+	maxSum := 0.0
+	for k := 0; k < samplePoints; k++ {
+		x := lo + float64(k)*(hi-lo)/float64(samplePoints-1)
+
+		sum := 0.0
+		for i := range nodes {
+			li := 1.0
+			for j := range nodes {
+				if i != j {
+					li *= (x - nodes[j]) / (nodes[i] - nodes[j])
+				}
+			}
+			sum += math.Abs(li)
+		}
+		maxSum = math.Max(maxSum, sum)
+	}
+
+	return maxSum
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// validateGrid2D проверяет, что z - таблица len(xs) x len(ys) не меньше чем 2 x 2,
+// а координаты узлов по каждой оси строго возрастают
+func validateGrid2D(xs, ys []float64, z [][]float64) error {
+	if len(xs) < 2 || len(ys) < 2 {
+		return fmt.Errorf("сетка должна содержать не меньше 2 узлов по каждой оси, получено %d x %d", len(xs), len(ys))
+	}
+	if len(z) != len(xs) {
+		return fmt.Errorf("в таблице %d строк, ожидалось %d", len(z), len(xs))
+	}
+	for i, row := range z {
+		if len(row) != len(ys) {
+			return fmt.Errorf("в строке %d таблицы %d значений, ожидалось %d", i, len(row), len(ys))
+		}
+	}
+	if err := validatePoints(axisPoints(xs, xs)); err != nil {
+		return fmt.Errorf("ось x: %w", err)
+	}
+	if err := validatePoints(axisPoints(ys, ys)); err != nil {
+		return fmt.Errorf("ось y: %w", err)
+	}
+	return nil
+}
+
+// axisPoints составляет из координат coords и значений values узлы одномерной интерполяции
+func axisPoints(coords, values []float64) []point {
+	points := make([]point, len(coords))
+	for i, c := range coords {
+		points[i] = point{x: c, y: values[i]}
+	}
+	return points
+}
+
+// grid2D - таблица z[i][j] = f(xs[i], ys[j]) на прямоугольной сетке. Узлы осей хранятся
+// в виде []point, чтобы искать ячейку точки через findInterval без копирования при каждом запросе
+type grid2D struct {
+	xAxis, yAxis []point
+	z            [][]float64
+}
+
+// newGrid2D создает сетку по координатам узлов xs, ys и таблице значений z
+func newGrid2D(xs, ys []float64, z [][]float64) (*grid2D, error) {
+	if err := validateGrid2D(xs, ys, z); err != nil {
+		return nil, err
+	}
+	return &grid2D{xAxis: axisPoints(xs, xs), yAxis: axisPoints(ys, ys), z: z}, nil
+}
+
+// bilinear вычисляет в точке (x, y) значение билинейной интерполяции по четырем узлам
+// ячейки, содержащей точку. Вне сетки используется ближайшая крайняя ячейка
+func (g *grid2D) bilinear(x, y float64) float64 {
+	i := findInterval(g.xAxis, x)
+	j := findInterval(g.yAxis, y)
+	tx := (x - g.xAxis[i].x) / (g.xAxis[i+1].x - g.xAxis[i].x)
+	ty := (y - g.yAxis[j].x) / (g.yAxis[j+1].x - g.yAxis[j].x)
+
+	return (1-tx)*(1-ty)*g.z[i][j] + tx*(1-ty)*g.z[i+1][j] +
+		(1-tx)*ty*g.z[i][j+1] + tx*ty*g.z[i+1][j+1]
+}
+
+// bicubicSpline - бикубическая сплайн-интерполяция таблицы на прямоугольной сетке.
+// Сплайны по y для каждой строки xs[i] строятся один раз в конструкторе; при вычислении
+// в точке (x, y) по их значениям в y строится сплайн по x. Используются условия
+// "not-a-knot", поэтому полиномы до третьей степени по каждой переменной восстанавливаются точно
+type bicubicSpline struct {
+	xs   []float64
+	rows []*cubicSpline // Сплайн по y для каждой строки таблицы
+}
+
+// newBicubicSpline создает бикубический сплайн по координатам узлов xs, ys и таблице значений z
+func newBicubicSpline(xs, ys []float64, z [][]float64) (*bicubicSpline, error) {
+	if err := validateGrid2D(xs, ys, z); err != nil {
+		return nil, err
+	}
+
+	rows := make([]*cubicSpline, len(xs))
+	for i, row := range z {
+		spline, err := newNotAKnotSpline(&interpolationData{points: axisPoints(ys, row)})
+		if err != nil {
+			return nil, fmt.Errorf("строка %d: %w", i, err)
+		}
+		rows[i] = spline
+	}
+	return &bicubicSpline{xs: xs, rows: rows}, nil
+}
+
+// evaluate вычисляет значение бикубического сплайна в точке (x, y). Сплайн по x зависит
+// от y, поэтому строится заново при каждом вычислении за O(len(xs))
+func (bs *bicubicSpline) evaluate(x, y float64) float64 {
+	column := make([]float64, len(bs.rows))
+	for i, row := range bs.rows {
+		column[i] = row.evaluate(y)
+	}
+
+	spline, err := newNotAKnotSpline(&interpolationData{points: axisPoints(bs.xs, column)})
+	if err != nil {
+		return math.NaN()
+	}
+	return spline.evaluate(x)
+}
+
+// bilinearInterpolate вычисляет в точке (x, y) значение билинейной интерполяции таблицы
+// z[i][j] = f(xs[i], ys[j]) (см. grid2D.bilinear). Для многих точек одной таблицы
+// выгоднее один раз создать newGrid2D. Для некорректной сетки возвращает NaN
+func bilinearInterpolate(xs, ys []float64, z [][]float64, x, y float64) float64 {
+	g, err := newGrid2D(xs, ys, z)
+	if err != nil {
+		return math.NaN()
+	}
+	return g.bilinear(x, y)
+}
+
+// bicubicInterpolate вычисляет в точке (x, y) значение бикубической сплайн-интерполяции
+// таблицы z[i][j] = f(xs[i], ys[j]) (см. bicubicSpline). Для многих точек одной таблицы
+// выгоднее один раз создать newBicubicSpline. Для некорректной сетки возвращает NaN
+func bicubicInterpolate(xs, ys []float64, z [][]float64, x, y float64) float64 {
+	bs, err := newBicubicSpline(xs, ys, z)
+	if err != nil {
+		return math.NaN()
+	}
+	return bs.evaluate(x, y)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// gridTable возвращает таблицу значений f в узлах xs x ys
+func gridTable(xs, ys []float64, f func(x, y float64) float64) [][]float64 {
+	z := make([][]float64, len(xs))
+	for i, x := range xs {
+		z[i] = make([]float64, len(ys))
+		for j, y := range ys {
+			z[i][j] = f(x, y)
+		}
+	}
+	return z
+}
+
+func TestBilinearExactOnLinearFunction(t *testing.T) {
+	plane := func(x, y float64) float64 { return x + y }
+	xs, ys := []float64{0, 0.5, 1.5, 3}, []float64{-1, 0, 2}
+	g, err := newGrid2D(xs, ys, gridTable(xs, ys, plane))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, x := range linspace(-0.5, 3.5, 17) {
+		for _, y := range linspace(-1.5, 2.5, 13) {
+			if got := g.bilinear(x, y); math.Abs(got-plane(x, y)) > 1e-12 {
+				t.Errorf("bilinear(%g, %g) = %g, ожидалось %g", x, y, got, plane(x, y))
+			}
+		}
+	}
+	if got := bilinearInterpolate(xs, ys, g.z, 1.2, 0.7); got != g.bilinear(1.2, 0.7) {
+		t.Errorf("bilinearInterpolate = %g, grid2D.bilinear = %g", got, g.bilinear(1.2, 0.7))
+	}
+}
+
+func TestBicubicBeatsBilinearOnQuadratic(t *testing.T) {
+	paraboloid := func(x, y float64) float64 { return x*x + y*y }
+	xs, ys := linspace(-1, 1, 6), linspace(-1, 2, 7)
+	z := gridTable(xs, ys, paraboloid)
+	g, err := newGrid2D(xs, ys, z)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs, err := newBicubicSpline(xs, ys, z)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bilinearErr, bicubicErr := 0.0, 0.0
+	for _, x := range linspace(-1, 1, 23) {
+		for _, y := range linspace(-1, 2, 29) {
+			bilinearErr = math.Max(bilinearErr, math.Abs(g.bilinear(x, y)-paraboloid(x, y)))
+			bicubicErr = math.Max(bicubicErr, math.Abs(bs.evaluate(x, y)-paraboloid(x, y)))
+		}
+	}
+	// Сплайны not-a-knot восстанавливают квадратичную функцию точно
+	if !(bicubicErr < 1e-12 && bilinearErr > 1e-2) {
+		t.Errorf("ошибка бикубической интерполяции %.3e, билинейной %.3e", bicubicErr, bilinearErr)
+	}
+	if got, want := bicubicInterpolate(xs, ys, z, 0.3, 1.1), bs.evaluate(0.3, 1.1); got != want {
+		t.Errorf("bicubicInterpolate = %g, bicubicSpline.evaluate = %g", got, want)
+	}
+}
+
+func TestGrid2DValidation(t *testing.T) {
+	xs, ys := []float64{0, 1, 2}, []float64{0, 1}
+	z := gridTable(xs, ys, func(x, y float64) float64 { return x * y })
+
+	for _, tc := range []struct {
+		name   string
+		xs, ys []float64
+		z      [][]float64
+	}{
+		{"одна строка", xs[:1], ys, z[:1]},
+		{"лишняя строка", xs[:2], ys, z},
+		{"короткая строка", xs, ys, [][]float64{{0, 0}, {0}, {0, 0}}},
+		{"неупорядоченная ось x", []float64{0, 2, 1}, ys, z},
+		{"повторяющийся узел y", xs, []float64{1, 1}, z},
+	} {
+		if _, err := newGrid2D(tc.xs, tc.ys, tc.z); err == nil {
+			t.Errorf("newGrid2D, %s: ожидалась ошибка", tc.name)
+		}
+		if _, err := newBicubicSpline(tc.xs, tc.ys, tc.z); err == nil {
+			t.Errorf("newBicubicSpline, %s: ожидалась ошибка", tc.name)
+		}
+		if got := bilinearInterpolate(tc.xs, tc.ys, tc.z, 0.5, 0.5); !math.IsNaN(got) {
+			t.Errorf("bilinearInterpolate, %s: %g, ожидалось NaN", tc.name, got)
+		}
+		if got := bicubicInterpolate(tc.xs, tc.ys, tc.z, 0.5, 0.5); !math.IsNaN(got) {
+			t.Errorf("bicubicInterpolate, %s: %g, ожидалось NaN", tc.name, got)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// interpolationTestSamples - количество точек, по которым в тестах сравниваются интерполянты
+const interpolationTestSamples = 200
+
+func TestHermiteReproducesCubic(t *testing.T) {
+	// testCubic(x) = 2 - x + 0.5x^2 + 0.3x^3, производная -1 + x + 0.9x^2
+	dCubic := func(x float64) float64 { return -1 + x + 0.9*x*x }
+	points := []hermitePoint{
+		{x: -1, y: testCubic(-1), dy: dCubic(-1)},
+		{x: 2, y: testCubic(2), dy: dCubic(2)},
+	}
+
+	for _, x := range linspace(-2, 3, interpolationTestSamples) {
+		if got, want := hermiteInterpolation(points, x), testCubic(x); math.Abs(got-want) > 1e-12*(1+math.Abs(want)) {
+			t.Errorf("H(%g) = %.15g, ожидалось %.15g", x, got, want)
+		}
+	}
+}
+
+func TestHermiteWithoutDerivativesIsLagrange(t *testing.T) {
+	data, err := createGrid(1, 5, 8, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	points := make([]hermitePoint, len(data.points))
+	for i, p := range data.points {
+		points[i] = hermitePoint{x: p.x, y: p.y, dy: math.NaN()}
+	}
+
+	for _, x := range linspace(data.a, data.b, interpolationTestSamples) {
+		if got, want := hermiteInterpolation(points, x), lagrangeInterpolation(data, x); math.Abs(got-want) > 1e-10 {
+			t.Errorf("H(%g) = %.15g, L(%g) = %.15g", x, got, x, want)
+		}
+	}
+}
+
+func TestHermiteImprovesOnLagrange(t *testing.T) {
+	data, err := createGrid(1, 5, 4, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	points := make([]hermitePoint, len(data.points))
+	for i, p := range data.points {
+		points[i] = hermitePoint{x: p.x, y: p.y, dy: testFunctionDerivative(p.x)}
+	}
+
+	hermiteErr := maxError(testFunction, func(x float64) float64 { return hermiteInterpolation(points, x) },
+		data.a, data.b, interpolationTestSamples)
+	lagrangeErr := maxError(testFunction, func(x float64) float64 { return lagrangeInterpolation(data, x) },
+		data.a, data.b, interpolationTestSamples)
+	if !(hermiteErr < lagrangeErr/10) {
+		t.Errorf("ошибка Эрмита %.3e не намного меньше ошибки Лагранжа %.3e", hermiteErr, lagrangeErr)
+	}
+}
+
+func TestNevilleMatchesLagrange(t *testing.T) {
+	for _, n := range []int{1, 2, 5, 10} {
+		data, err := createChebyshevGrid(1, 5, n, testFunction)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, x := range linspace(data.a, data.b, interpolationTestSamples) {
+			got, _ := nevilleInterpolation(data.points, x)
+			if want := lagrangeInterpolation(data, x); math.Abs(got-want) > 1e-10 {
+				t.Errorf("N = %d: P(%g) по Невиллу %.15g, по Лагранжу %.15g", n, x, got, want)
+			}
+		}
+	}
+}
+
+func TestNevilleErrorEstimateShrinks(t *testing.T) {
+	// Добавляем узлы симметрично вокруг x, чтобы он оставался внутри отрезка интерполяции
+	const x = 2.55
+	prevEstimate := math.Inf(1)
+	for k := 1; k <= 5; k++ {
+		var points []point
+		for i := -k; i < k; i++ {
+			xi := 2.5 + 0.25*float64(i) + 0.125
+			points = append(points, point{x: xi, y: testFunction(xi)})
+		}
+		_, estimate := nevilleInterpolation(points, x)
+		if !(estimate < prevEstimate) {
+			t.Errorf("%d узлов: оценка погрешности %.3e не меньше предыдущей %.3e", len(points), estimate, prevEstimate)
+		}
+		prevEstimate = estimate
+	}
+}
+
+func TestNevilleEmpty(t *testing.T) {
+	value, estimate := nevilleInterpolation(nil, 1)
+	if !math.IsNaN(value) || !math.IsNaN(estimate) {
+		t.Errorf("nevilleInterpolation(nil) = %g, %g, ожидалось NaN, NaN", value, estimate)
+	}
+}
+
+// testFunctionDerivativeBound возвращает оценку max|f^(k)| тестовой функции на [1, 5], k >= 2.
+// Для f = x*ln(x+1)/ln10 - 1: f^(k) = (x*g^(k) + k*g^(k-1)) / ln10, g = ln(x+1),
+// |g^(m)| = (m-1)!/(x+1)^m, и оба слагаемых убывают по x, поэтому максимум - в x = 1
+func testFunctionDerivativeBound(k int) float64 {
+	factorial := func(m int) float64 { return math.Gamma(float64(m + 1)) }
+	return (factorial(k-1)/math.Pow(2, float64(k)) + float64(k)*factorial(k-2)/math.Pow(2, float64(k-1))) / math.Ln10
+}
+
+func TestLagrangeErrorBoundHolds(t *testing.T) {
+	for _, n := range []int{3, 6, 10} {
+		for _, grid := range []func(a, b float64, n int, f func(float64) float64) (*interpolationData, error){
+			createGrid, createChebyshevGrid,
+		} {
+			data, err := grid(1, 5, n, testFunction)
+			if err != nil {
+				t.Fatal(err)
+			}
+			derivBound := testFunctionDerivativeBound(n + 1)
+
+			for _, x := range linspace(1, 5, interpolationTestSamples) {
+				actual := math.Abs(testFunction(x) - lagrangeInterpolation(data, x))
+				bound := lagrangeErrorBound(data, derivBound, x)
+				if actual > bound*(1+1e-9)+1e-14 {
+					t.Errorf("N = %d, x = %g: ошибка %.3e больше оценки %.3e", n, x, actual, bound)
+				}
+			}
+		}
+	}
+}
+
+func TestLagrangeInterpolationVec(t *testing.T) {
+	nodes := linspace(0, math.Pi, 13)
+	points := make([]vecPoint, len(nodes))
+	for i, x := range nodes {
+		points[i] = vecPoint{x: x, y: []float64{math.Cos(x), math.Sin(x)}}
+	}
+
+	for _, x := range linspace(0, math.Pi, interpolationTestSamples) {
+		v := lagrangeInterpolationVec(points, x)
+		if len(v) != 2 {
+			t.Fatalf("x = %g: %d компонент, ожидалось 2", x, len(v))
+		}
+		if math.Abs(v[0]-math.Cos(x)) > 1e-6 || math.Abs(v[1]-math.Sin(x)) > 1e-6 {
+			t.Errorf("x = %g: (%g, %g), ожидалось (%g, %g)", x, v[0], v[1], math.Cos(x), math.Sin(x))
+		}
+	}
+
+	// Каждая компонента совпадает со скалярной интерполяцией
+	sinData, err := sampleAt(nodes, math.Sin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, x := range []float64{0.1, 1.3, 2.9} {
+		if got, want := lagrangeInterpolationVec(points, x)[1], lagrangeInterpolation(sinData, x); math.Abs(got-want) > 1e-14 {
+			t.Errorf("x = %g: компонента sin %.17g, скалярная интерполяция %.17g", x, got, want)
+		}
+	}
+
+	if v := lagrangeInterpolationVec(nil, 1); v != nil {
+		t.Errorf("для пустого набора узлов получено %v", v)
+	}
+}
+
+func TestLebesgueConstantChebyshevSmaller(t *testing.T) {
+	const n = 20
+	uniform := lebesgueConstant(uniformNodes(-1, 1, n), 2000)
+	chebyshev := lebesgueConstant(chebyshevNodes(-1, 1, n), 2000)
+
+	// Для узлов Чебышева константа растет как (2/pi)*ln(n), для равномерных - как 2^n/(e*n*ln n)
+	if !(chebyshev < 4 && uniform > 1e4) {
+		t.Errorf("N = %d: константа Лебега равномерных узлов %.3e, узлов Чебышева %.3f", n, uniform, chebyshev)
+	}
+
+	// Для двух узлов функция Лебега на отрезке между ними тождественно равна 1
+	if c := lebesgueConstant([]float64{0, 1}, 100); math.Abs(c-1) > 1e-12 {
+		t.Errorf("константа Лебега двух узлов %g, ожидалось 1", c)
+	}
+}
+
+func TestNodePolynomial(t *testing.T) {
+	const n = 10
+	uniform, chebyshev := uniformNodes(-1, 1, n), chebyshevNodes(-1, 1, n)
+	for _, nodes := range [][]float64{uniform, chebyshev} {
+		for _, xi := range nodes {
+			if w := nodePolynomial(nodes, xi); w != 0 {
+				t.Errorf("omega(%g) = %g, ожидался 0", xi, w)
+			}
+		}
+	}
+	if got := nodePolynomial([]float64{1, 2, 4}, 3); got != -2 {
+		t.Errorf("omega(3) по узлам 1, 2, 4 = %g, ожидалось -2", got)
+	}
+
+	maxOmega := func(nodes []float64) float64 {
+		m := 0.0
+		for _, x := range linspace(-1, 1, 10*interpolationTestSamples) {
+			m = math.Max(m, math.Abs(nodePolynomial(nodes, x)))
+		}
+		return m
+	}
+	// У узлов Чебышева max|omega| на [-1, 1] равен 2^-n, меньше, чем у любых других n+1 узлов
+	uniformMax, chebyshevMax := maxOmega(uniform), maxOmega(chebyshev)
+	if math.Abs(chebyshevMax-math.Pow(2, -n)) > 1e-3*math.Pow(2, -n) {
+		t.Errorf("max|omega| на узлах Чебышева %.6e, ожидалось %.6e", chebyshevMax, math.Pow(2, -n))
+	}
+	if !(uniformMax > 5*chebyshevMax) {
+		t.Errorf("max|omega| на равномерных узлах %.3e, на узлах Чебышева %.3e", uniformMax, chebyshevMax)
+	}
+}
+
+func TestRationalInterpolationBeatsLagrangeOnRunge(t *testing.T) {
+	data, err := createGrid(-1, 1, 10, rungeFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rational := func(x float64) float64 {
+		y, _ := rationalInterpolation(data.points, x)
+		return y
+	}
+	lagrange := func(x float64) float64 { return lagrangeInterpolation(data, x) }
+
+	// Функция Рунге сама рациональна, и диагональная рациональная функция по 11 узлам ее восстанавливает
+	rationalErr := maxError(rungeFunction, rational, -1, 1, interpolationTestSamples)
+	lagrangeErr := maxError(rungeFunction, lagrange, -1, 1, interpolationTestSamples)
+	if !(rationalErr < 1e-10 && lagrangeErr > 1) {
+		t.Errorf("ошибка рациональной интерполяции %.3e, Лагранжа %.3e", rationalErr, lagrangeErr)
+	}
+}
+
+func TestRationalInterpolationPole(t *testing.T) {
+	// Узлы функции 1/x по обе стороны от полюса
+	points := []point{{-2, -0.5}, {-1, -1}, {1, 1}, {2, 0.5}}
+	if y, dy := rationalInterpolation(points, 0.5); math.Abs(y-2) > 1e-12 || dy > 1e-12 {
+		t.Errorf("R(0.5) = %g с оценкой погрешности %g, ожидалось 2", y, dy)
+	}
+	if y, dy := rationalInterpolation(points, 1); y != 1 || dy != 0 {
+		t.Errorf("в узле R(1) = %g, %g, ожидалось 1, 0", y, dy)
+	}
+
+	// В полюсе знаменатель обращается в ноль: значение остается числом, погрешность бесконечна
+	if y, dy := rationalInterpolation(points, 0); math.IsNaN(y) || !math.IsInf(dy, 1) {
+		t.Errorf("в полюсе R(0) = %g, оценка погрешности %g, ожидалась +Inf", y, dy)
+	}
+	if y, dy := rationalInterpolation(nil, 0); !math.IsNaN(y) || !math.IsNaN(dy) {
+		t.Errorf("без узлов (%g, %g), ожидалось NaN", y, dy)
+	}
+}
+
+func TestFloaterHormannOnRunge(t *testing.T) {
+	data, err := createGrid(-1, 1, 20, rungeFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fh := func(x float64) float64 { return floaterHormann(data.points, 3, x) }
+	lagrange := func(x float64) float64 { return lagrangeInterpolation(data, x) }
+
+	// На 21 равноотстоящем узле ошибка Лагранжа около 60
+	fhErr := maxError(rungeFunction, fh, -1, 1, interpolationTestSamples)
+	lagrangeErr := maxError(rungeFunction, lagrange, -1, 1, interpolationTestSamples)
+	if !(fhErr < 1e-2 && fhErr < 1e-3*lagrangeErr) {
+		t.Errorf("ошибка Флоатера-Хормана %.3e, Лагранжа %.3e", fhErr, lagrangeErr)
+	}
+
+	for _, p := range data.points {
+		if got := floaterHormann(data.points, 3, p.x); got != p.y {
+			t.Errorf("в узле x = %g: %g, ожидалось %g", p.x, got, p.y)
+		}
+	}
+
+	// При d = n получается полином Лагранжа
+	small, err := createGrid(1, 5, 6, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, x := range linspace(1.1, 4.9, 9) {
+		if got, want := floaterHormann(small.points, 6, x), lagrangeInterpolation(small, x); math.Abs(got-want) > 1e-12 {
+			t.Errorf("d = n, x = %g: %.15g, Лагранж %.15g", x, got, want)
+		}
+	}
+	if got := floaterHormann(nil, 3, 0); !math.IsNaN(got) {
+		t.Errorf("без узлов %g, ожидалось NaN", got)
+	}
+}
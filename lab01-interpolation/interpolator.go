@@ -0,0 +1,203 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Interpolator - общий интерфейс методов интерполяции
+type Interpolator interface {
+	// Evaluate вычисляет значение интерполянта в точке x
+	Evaluate(x float64) float64
+	// Name возвращает краткое название метода для таблиц и графиков
+	Name() string
+	// Domain возвращает отрезок между крайними узлами интерполяции
+	Domain() (lo, hi float64)
+}
+
+// evaluator - любой сплайн с методами evaluate и domain (cubicSpline, quadraticSpline, hermiteSpline)
+type evaluator interface {
+	evaluate(x float64) float64
+	domain() (lo, hi float64)
+}
+
+// nodeRange возвращает минимальную и максимальную абсциссы узлов
+func nodeRange(points []point) (lo, hi float64) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, p := range points {
+		lo = math.Min(lo, p.x)
+		hi = math.Max(hi, p.x)
+	}
+	return lo, hi
+}
+
+// extrapolate вычисляет значение интерполянта в точке x и сообщает, лежит ли x вне отрезка
+// между крайними узлами. Вне этого отрезка результат детерминирован, но ненадежен:
+// полином Лагранжа вычисляется по той же формуле, сплайны и кусочно-линейная интерполяция
+// продолжаются функцией ближайшего крайнего отрезка
+func extrapolate(interp Interpolator, x float64) (float64, bool) {
+	lo, hi := interp.Domain()
+	return interp.Evaluate(x), x < lo || x > hi
+}
+
+// asFunc возвращает интерполянт как функцию одной переменной, чтобы передавать его
+// в квадратурные формулы, методы поиска корней и другие функции, принимающие f(x)
+func asFunc(interp Interpolator) func(float64) float64 {
+	return interp.Evaluate
+}
+
+// lagrangeInterpolator - полином Лагранжа по узлам сетки
+type lagrangeInterpolator struct {
+	data *interpolationData
+	name string
+}
+
+// newLagrangeInterpolator создает интерполятор Лагранжа по сетке data
+func newLagrangeInterpolator(name string, data *interpolationData) (*lagrangeInterpolator, error) {
+	if err := validatePoints(data.points); err != nil {
+		return nil, err
+	}
+	return &lagrangeInterpolator{data: data, name: name}, nil
+}
+
+func (li *lagrangeInterpolator) Evaluate(x float64) float64 {
+	return lagrangeInterpolation(li.data, x)
+}
+
+func (li *lagrangeInterpolator) Name() string {
+	return li.name
+}
+
+func (li *lagrangeInterpolator) Domain() (lo, hi float64) {
+	return nodeRange(li.data.points)
+}
+
+// timedInterpolator - интерполятор, запомнивший время своего построения. Методы без
+// предварительных вычислений (полином Лагранжа, кусочно-линейная интерполяция) его не реализуют
+type timedInterpolator interface {
+	Interpolator
+	// BuildTime возвращает время построения интерполянта
+	BuildTime() time.Duration
+}
+
+// splineInterpolator адаптирует сплайн к интерфейсу Interpolator
+type splineInterpolator struct {
+	spline    evaluator
+	name      string
+	buildTime time.Duration // Время построения сплайна, если он построен buildSplineInterpolator
+}
+
+// newSplineInterpolator создает интерполятор по готовому сплайну
+func newSplineInterpolator(name string, spline evaluator) *splineInterpolator {
+	return &splineInterpolator{spline: spline, name: name}
+}
+
+// buildSplineInterpolator строит сплайн функцией build и создает по нему интерполятор,
+// запоминая время построения
+func buildSplineInterpolator(name string, build func() (evaluator, error)) (*splineInterpolator, error) {
+	start := time.Now()
+	spline, err := build()
+	if err != nil {
+		return nil, err
+	}
+	return &splineInterpolator{spline: spline, name: name, buildTime: time.Since(start)}, nil
+}
+
+func (si *splineInterpolator) Evaluate(x float64) float64 {
+	return si.spline.evaluate(x)
+}
+
+func (si *splineInterpolator) Name() string {
+	return si.name
+}
+
+func (si *splineInterpolator) Domain() (lo, hi float64) {
+	return si.spline.domain()
+}
+
+func (si *splineInterpolator) BuildTime() time.Duration {
+	return si.buildTime
+}
+
+// linearInterpolator - кусочно-линейная интерполяция по узлам сетки
+type linearInterpolator struct {
+	data *interpolationData
+}
+
+// newLinearInterpolator создает кусочно-линейный интерполятор по сетке data
+func newLinearInterpolator(data *interpolationData) (*linearInterpolator, error) {
+	if err := validateSplinePoints(data.points); err != nil {
+		return nil, err
+	}
+	return &linearInterpolator{data: data}, nil
+}
+
+func (li *linearInterpolator) Evaluate(x float64) float64 {
+	return linearInterpolation(li.data, x)
+}
+
+func (li *linearInterpolator) Name() string {
+	return "Линейная"
+}
+
+func (li *linearInterpolator) Domain() (lo, hi float64) {
+	return nodeRange(li.data.points)
+}
+
+// defaultInterpolators возвращает набор методов, сравниваемых в основной программе
+func defaultInterpolators(uniformData, chebyshevData, chebyshev2Data *interpolationData) ([]Interpolator, error) {
+	grids := []struct {
+		name string
+		data *interpolationData
+	}{
+		{"Лагранж равн", uniformData},
+		{"Лагранж Чеб", chebyshevData},
+		{"Лагранж Чеб2", chebyshev2Data},
+	}
+
+	methods := make([]Interpolator, 0, len(grids)+3)
+	for _, g := range grids {
+		li, err := newLagrangeInterpolator(g.name, g.data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", g.name, err)
+		}
+		methods = append(methods, li)
+	}
+
+	cubic, err := buildSplineInterpolator("Куб. сплайн", func() (evaluator, error) {
+		return newCubicSpline(uniformData)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("кубический сплайн: %w", err)
+	}
+	quadratic, err := buildSplineInterpolator("Кв. сплайн", func() (evaluator, error) {
+		return newQuadraticSpline(uniformData)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("квадратичный сплайн: %w", err)
+	}
+	linear, err := newLinearInterpolator(uniformData)
+	if err != nil {
+		return nil, fmt.Errorf("линейная интерполяция: %w", err)
+	}
+
+	return append(methods, cubic, quadratic, linear), nil
+}
+
+// evaluateAll вычисляет в точке x значения основных методов: полиномов Лагранжа по равномерной
+// сетке и по узлам Чебышева, кубического сплайна и кусочно-линейной интерполяции по равномерной
+// сетке. Ключи совпадают с названиями методов в таблицах. Если передана функция testFunc,
+// в результат добавляется и ее точное значение с ключом "f(x)"
+func evaluateAll(uniformData, chebyshevData *interpolationData, spline *cubicSpline, x float64, testFunc ...func(float64) float64) map[string]float64 {
+	values := map[string]float64{
+		"Лагранж равн": lagrangeInterpolation(uniformData, x),
+		"Лагранж Чеб":  lagrangeInterpolation(chebyshevData, x),
+		"Куб. сплайн":  spline.evaluate(x),
+		"Линейная":     linearInterpolation(uniformData, x),
+	}
+	if len(testFunc) > 0 && testFunc[0] != nil {
+		values["f(x)"] = testFunc[0](x)
+	}
+	return values
+}
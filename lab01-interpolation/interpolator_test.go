@@ -0,0 +1,142 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// testInterpolators возвращает методы сравнения основной программы по n+1 узлам тестовой функции на [1, 5]
+func testInterpolators(t *testing.T, n int) []Interpolator {
+	t.Helper()
+	uniform, err := createGrid(1, 5, n, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chebyshev, err := createChebyshevGrid(1, 5, n, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chebyshev2, err := createChebyshevGrid2(1, 5, n, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	methods, err := defaultInterpolators(uniform, chebyshev, chebyshev2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return methods
+}
+
+func TestInterpolatorsFinite(t *testing.T) {
+	methods := testInterpolators(t, 10)
+	names := make(map[string]bool)
+	for _, m := range methods {
+		if names[m.Name()] {
+			t.Errorf("повторяющееся название метода %q", m.Name())
+		}
+		names[m.Name()] = true
+
+		for _, x := range linspace(1, 5, 100) {
+			y := m.Evaluate(x)
+			if math.IsNaN(y) || math.IsInf(y, 0) {
+				t.Errorf("%s(%g) = %g", m.Name(), x, y)
+			}
+			if math.Abs(y-testFunction(x)) > 1e-2 {
+				t.Errorf("%s(%g) = %g далеко от f(x) = %g", m.Name(), x, y, testFunction(x))
+			}
+		}
+	}
+}
+
+func TestExtrapolate(t *testing.T) {
+	for _, m := range testInterpolators(t, 10) {
+		lo, hi := m.Domain()
+		for _, x := range []float64{lo - 1, lo - 1e-9, hi + 1e-9, hi + 1} {
+			value, outside := extrapolate(m, x)
+			if !outside {
+				t.Errorf("%s: x = %g вне [%g, %g], но экстраполяция не отмечена", m.Name(), x, lo, hi)
+			}
+			if value != m.Evaluate(x) {
+				t.Errorf("%s: extrapolate(%g) = %g, Evaluate = %g", m.Name(), x, value, m.Evaluate(x))
+			}
+		}
+		for _, x := range []float64{lo, (lo + hi) / 2, hi} {
+			if _, outside := extrapolate(m, x); outside {
+				t.Errorf("%s: x = %g внутри [%g, %g], но отмечена экстраполяция", m.Name(), x, lo, hi)
+			}
+		}
+	}
+}
+
+func TestEvaluateAll(t *testing.T) {
+	uniform, err := createGrid(1, 5, 10, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chebyshev, err := createChebyshevGrid(1, 5, 10, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spline, err := newCubicSpline(uniform)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const x = 3.1
+	values := evaluateAll(uniform, chebyshev, spline, x, testFunction)
+	keys := []string{"Лагранж равн", "Лагранж Чеб", "Куб. сплайн", "Линейная", "f(x)"}
+	if len(values) != len(keys) {
+		t.Errorf("%d значений: %v, ожидались ключи %v", len(values), values, keys)
+	}
+	for _, key := range keys {
+		v, ok := values[key]
+		if !ok {
+			t.Errorf("нет значения %q", key)
+			continue
+		}
+		if math.IsNaN(v) || math.IsInf(v, 0) || math.Abs(v-testFunction(x)) > 1e-2 {
+			t.Errorf("%s(%g) = %g, f(x) = %g", key, x, v, testFunction(x))
+		}
+	}
+
+	if _, ok := evaluateAll(uniform, chebyshev, spline, x)["f(x)"]; ok {
+		t.Error("без функции в результате не должно быть точного значения")
+	}
+}
+
+func TestAsFuncComposesWithQuadratureAndRoots(t *testing.T) {
+	data, err := createGrid(1, 5, 20, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spline, err := newCubicSpline(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := asFunc(newSplineInterpolator("Куб. сплайн", spline))
+
+	splineIntegral, err := simpsonRule(f, 1, 5, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trueIntegral, err := simpsonRule(testFunction, 1, 5, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(splineIntegral-trueIntegral) > 1e-4 || math.Abs(splineIntegral-spline.integrate(1, 5)) > 1e-8 {
+		t.Errorf("интеграл сплайна %.10f, функции %.10f, точный интеграл сплайна %.10f",
+			splineIntegral, trueIntegral, spline.integrate(1, 5))
+	}
+
+	lagrange, err := newLagrangeInterpolator("Лагранж равн", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := bisection(asFunc(lagrange), 1, 5, 1e-12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(root-testFunctionRoot) > 1e-8 {
+		t.Errorf("корень интерполянта %.12f, корень функции %.12f", root, testFunctionRoot)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import "fmt"
+
+// polyFit находит коэффициенты полинома степени degree, приближающего точки по методу
+// наименьших квадратов. Решается нормальная система (V^T V) c = V^T y, где V - матрица
+// Вандермонда. Коэффициенты возвращаются по возрастанию степеней
+func polyFit(points []point, degree int) ([]float64, error) {
+	weights := make([]float64, len(points))
+	for i := range weights {
+		weights[i] = 1
+	}
+	return polyFitWeighted(points, weights, degree)
+}
+
+// polyFitWeighted находит коэффициенты полинома степени degree по взвешенному методу
+// наименьших квадратов: минимизируется sum(w(i) * (P(x(i)) - y(i))^2). Веса входят в нормальную
+// систему (V^T W V) c = V^T W y как диагональная матрица W; точкам с большей погрешностью
+// следует давать меньший вес
+func polyFitWeighted(points []point, weights []float64, degree int) ([]float64, error) {
+	if err := checkFitDegree(points, degree); err != nil {
+		return nil, err
+	}
+	if len(weights) != len(points) {
+		return nil, fmt.Errorf("количество весов %d не совпадает с количеством точек %d", len(weights), len(points))
+	}
+	for i, w := range weights {
+		if !(w >= 0) {
+			return nil, fmt.Errorf("вес точки %d должен быть неотрицательным, получено %g", i, w)
+		}
+	}
+
+	m := degree + 1
+
+	// Взвешенные суммы степеней w * x^k для k = 0..2*degree и правая часть sum(w * y * x^k)
+	powerSums := make([]float64, 2*m-1)
+	rhs := make([]float64, m)
+	for i, p := range points {
+		xk := weights[i]
+		for k := 0; k < 2*m-1; k++ {
+			powerSums[k] += xk
+			if k < m {
+				rhs[k] += p.y * xk
+			}
+			xk *= p.x
+		}
+	}
+
+	// Матрица нормальной системы: a(i, j) = sum(w * x^(i+j))
+	a := newMatrix(m, m)
+	for i := 0; i < m; i++ {
+		for j := 0; j < m; j++ {
+			a.set(i, j, powerSums[i+j])
+		}
+	}
+
+	return solveLinearSystem(a, rhs)
+}
+
+// polyFitQR находит коэффициенты полинома наименьших квадратов степени degree, решая
+// переопределенную систему с матрицей Вандермонда через QR-разложение. Точнее polyFit
+// при больших степенях, когда нормальная система плохо обусловлена
+func polyFitQR(points []point, degree int) ([]float64, error) {
+	if err := checkFitDegree(points, degree); err != nil {
+		return nil, err
+	}
+
+	y := make([]float64, len(points))
+	for i, p := range points {
+		y[i] = p.y
+	}
+
+	return qrSolveLeastSquares(vandermondeMatrix(points, degree), y)
+}
+
+// checkFitDegree проверяет, что по точкам можно построить полином степени degree
+func checkFitDegree(points []point, degree int) error {
+	if degree < 0 {
+		return fmt.Errorf("степень полинома должна быть неотрицательной, получено %d", degree)
+	}
+	if len(points) < degree+1 {
+		return fmt.Errorf("для полинома степени %d нужно не меньше %d точек, получено %d",
+			degree, degree+1, len(points))
+	}
+	return nil
+}
+
+// vandermondeMatrix строит матрицу Вандермонда v(i, k) = x(i)^k размера len(points) x (degree+1)
+func vandermondeMatrix(points []point, degree int) *matrix {
+	v := newMatrix(len(points), degree+1)
+	for i, p := range points {
+		xk := 1.0
+		for k := 0; k <= degree; k++ {
+			v.set(i, k, xk)
+			xk *= p.x
+		}
+	}
+	return v
+}
+
+// polyEval вычисляет значение полинома с коэффициентами coeffs (по возрастанию степеней)
+// в точке x; см. hornerEval
+func polyEval(coeffs []float64, x float64) float64 {
+	return hornerEval(coeffs, x)
+}
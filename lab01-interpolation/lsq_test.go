@@ -0,0 +1,173 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestPolyFitNoisyLine(t *testing.T) {
+	// y = 2 + 0.5x с равномерным шумом амплитуды 0.1 в 201 точке
+	line := func(x float64) float64 { return 2 + 0.5*x }
+	data, err := createGrid(0, 10, 200, line)
+	if err != nil {
+		t.Fatal(err)
+	}
+	noisy := addNoise(data, 0.1, 42)
+
+	coeffs, err := polyFit(noisy.points, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(coeffs) != 2 {
+		t.Fatalf("%d коэффициентов, ожидалось 2", len(coeffs))
+	}
+	if math.Abs(coeffs[0]-2) > 0.05 || math.Abs(coeffs[1]-0.5) > 0.01 {
+		t.Errorf("прямая %g + %gx, ожидалось 2 + 0.5x", coeffs[0], coeffs[1])
+	}
+	if math.Abs(polyEval(coeffs, 4)-line(4)) > 0.05 {
+		t.Errorf("P(4) = %g, ожидалось около %g", polyEval(coeffs, 4), line(4))
+	}
+}
+
+func TestPolyFitDegreeErrors(t *testing.T) {
+	points := []point{{0, 1}, {1, 2}}
+	if _, err := polyFit(points, -1); err == nil {
+		t.Error("ожидалась ошибка для отрицательной степени")
+	}
+	if _, err := polyFit(points, 2); err == nil {
+		t.Error("ожидалась ошибка, когда точек меньше, чем коэффициентов")
+	}
+}
+
+func TestPolyFitQRMatchesNormalEquations(t *testing.T) {
+	// Хорошо обусловленная задача: парабола по зашумленным точкам на [-1, 1]
+	data, err := createGrid(-1, 1, 50, func(x float64) float64 { return 1 - x + 2*x*x })
+	if err != nil {
+		t.Fatal(err)
+	}
+	noisy := addNoise(data, 0.05, 7)
+
+	normal, err := polyFit(noisy.points, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	qr, err := polyFitQR(noisy.points, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !vectorsClose(normal, qr, 1e-10) {
+		t.Errorf("нормальные уравнения дают %v, QR-разложение %v", normal, qr)
+	}
+}
+
+func TestPolyFitQRVandermondeDegree8(t *testing.T) {
+	// Полином степени 8 по точным значениям на [0, 10]: матрица нормальной системы
+	// обусловлена как квадрат матрицы Вандермонда, и коэффициенты теряют точность
+	want := []float64{1, -1, 1, -1, 1, -1, 1, -1, 1}
+	data, err := createGrid(0, 10, 40, func(x float64) float64 { return hornerEval(want, x) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	coeffError := func(coeffs []float64) float64 {
+		e := 0.0
+		for k := range want {
+			e = math.Max(e, math.Abs(coeffs[k]-want[k]))
+		}
+		return e
+	}
+
+	qr, err := polyFitQR(data.points, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	qrErr := coeffError(qr)
+	if qrErr > 1e-6 {
+		t.Errorf("ошибка коэффициентов через QR-разложение %.3e", qrErr)
+	}
+
+	normal, err := polyFit(data.points, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// На этих данных ошибка нормальных уравнений порядка 1e-3, QR-разложения - порядка 1e-7
+	if normalErr := coeffError(normal); !(100*qrErr < normalErr) {
+		t.Errorf("ошибка через QR-разложение %.3e не намного меньше ошибки нормальных уравнений %.3e", qrErr, normalErr)
+	}
+}
+
+func TestPolyFitQRRankDeficient(t *testing.T) {
+	// Пять точек, но только два различных x: по ним нельзя однозначно построить
+	// полином второй степени, столбцы матрицы Вандермонда линейно зависимы
+	points := []point{{1, 2}, {1, 2.1}, {3, 4.1}, {3, 3.9}, {1, 1.9}}
+
+	if _, err := polyFitQR(points, 2); !errors.Is(err, errSingularMatrix) {
+		t.Errorf("polyFitQR: ожидалась ошибка errSingularMatrix, получено %v", err)
+	}
+	if _, err := polyFit(points, 2); !errors.Is(err, errSingularMatrix) {
+		t.Errorf("polyFit: ожидалась ошибка errSingularMatrix, получено %v", err)
+	}
+
+	// Прямая по тем же точкам определена однозначно
+	line, err := polyFitQR(points, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(line[0]-1) > 1e-12 || math.Abs(line[1]-1) > 1e-12 {
+		t.Errorf("коэффициенты прямой %v, ожидалось [1 1]", line)
+	}
+}
+
+func TestPolyFitWeighted(t *testing.T) {
+	data, err := createGrid(0, 4, 12, func(x float64) float64 { return 1 + 0.5*x })
+	if err != nil {
+		t.Fatal(err)
+	}
+	noisy := addNoise(data, 0.3, 5)
+	points := noisy.points
+
+	// Единичные веса дают ту же систему, что и polyFit
+	ones := make([]float64, len(points))
+	for i := range ones {
+		ones[i] = 1
+	}
+	weighted, err := polyFitWeighted(points, ones, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err := polyFit(points, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(weighted, plain) {
+		t.Errorf("единичные веса дают %v, polyFit - %v", weighted, plain)
+	}
+
+	// Большой вес одной точки притягивает к ней прямую
+	const k = 5
+	heavy := slices.Clone(ones)
+	heavy[k] = 1e4
+	pulled, err := polyFitWeighted(points, heavy, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	line, err := polyFit(points, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := points[k]
+	pulledDist, plainDist := math.Abs(polyEval(pulled, p.x)-p.y), math.Abs(polyEval(line, p.x)-p.y)
+	if !(pulledDist < 1e-3 && pulledDist < plainDist/10) {
+		t.Errorf("расстояние до тяжелой точки %.3e, без весов %.3e", pulledDist, plainDist)
+	}
+
+	if _, err := polyFitWeighted(points, ones[1:], 1); err == nil {
+		t.Error("ожидалась ошибка при несовпадении количества весов и точек")
+	}
+	ones[0] = -1
+	if _, err := polyFitWeighted(points, ones, 1); err == nil {
+		t.Error("ожидалась ошибка для отрицательного веса")
+	}
+}
@@ -1,9 +1,20 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"math"
+	"math/rand"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // point представляет точку (x, y)
@@ -23,20 +34,34 @@ func testFunction(x float64) float64 {
 	return x*math.Log10(x+1) - 1
 }
 
+// testFunctionDerivative - аналитическая производная тестовой функции
+func testFunctionDerivative(x float64) float64 {
+	return math.Log10(x+1) + x/((x+1)*math.Ln10)
+}
+
 // moduleFunction - тестовая функция модуля
 func moduleFunction(x float64) float64 {
 	return math.Abs(x)
 }
 
-// createGrid создает равномерную сетку точек
-func createGrid(a, b float64, n int, f func(float64) float64) *interpolationData {
-	h := (b - a) / float64(n)
-	points := make([]point, n+1)
+// rungeFunction - функция Рунге 1 / (1 + 25x^2). На [-1, 1] полином Лагранжа по равномерным
+// узлам расходится у концов отрезка с ростом N, а по узлам Чебышева - сходится
+func rungeFunction(x float64) float64 {
+	return 1 / (1 + 25*x*x)
+}
 
-	for i := 0; i <= n; i++ {
-		x := a + float64(i)*h
-		y := f(x)
-		points[i] = point{x: x, y: y}
+// createCustomGrid создает сетку из n+1 узлов, расположенных на [a, b] генератором nodeGen,
+// и вычисляет в них значения функции f. Если в каком-то узле f не конечна (NaN или
+// бесконечность, например при выходе за область определения), возвращает ошибку
+func createCustomGrid(a, b float64, n int, f func(float64) float64, nodeGen func(a, b float64, n int) []float64) (*interpolationData, error) {
+	nodes := nodeGen(a, b, n)
+	points := make([]point, len(nodes))
+
+	for i, x := range nodes {
+		points[i] = point{x: x, y: f(x)}
+	}
+	if err := checkFinite(points); err != nil {
+		return nil, err
 	}
 
 	return &interpolationData{
@@ -44,28 +69,224 @@ func createGrid(a, b float64, n int, f func(float64) float64) *interpolationData
 		a:      a,
 		b:      b,
 		n:      n,
+	}, nil
+}
+
+// checkFinite проверяет, что значения функции во всех узлах конечны
+func checkFinite(points []point) error {
+	for _, p := range points {
+		if math.IsNaN(p.y) || math.IsInf(p.y, 0) {
+			return fmt.Errorf("значение функции в узле x = %g не конечно: %g", p.x, p.y)
+		}
 	}
+	return nil
 }
 
-// createChebyshevGrid создает сетку точек на основе узлов Чебышева
-func createChebyshevGrid(a, b float64, n int, f func(float64) float64) *interpolationData {
-	points := make([]point, n+1)
+// sampleAt создает сетку по явно заданным узлам xs, которые должны строго возрастать,
+// и вычисляет в них значения функции f. Границы a, b и n берутся из xs
+func sampleAt(xs []float64, f func(float64) float64) (*interpolationData, error) {
+	if len(xs) == 0 {
+		return nil, errors.New("не задано ни одного узла")
+	}
+
+	points := make([]point, len(xs))
+	for i, x := range xs {
+		points[i] = point{x: x}
+	}
+	if err := validatePoints(points); err != nil {
+		return nil, err
+	}
+	for i := range points {
+		points[i].y = f(points[i].x)
+	}
+	if err := checkFinite(points); err != nil {
+		return nil, err
+	}
+
+	return &interpolationData{
+		points: points,
+		a:      xs[0],
+		b:      xs[len(xs)-1],
+		n:      len(xs) - 1,
+	}, nil
+}
+
+// linspace возвращает n равноотстоящих точек отрезка [a, b], включая оба конца
+// (при n = 1 - только a). Используется для выборок, по которым строятся таблицы и графики
+func linspace(a, b float64, n int) []float64 {
+	switch {
+	case n < 1:
+		return nil
+	case n == 1:
+		return []float64{a}
+	}
+
+	xs := make([]float64, n)
+	for i := range xs {
+		xs[i] = a + float64(i)*(b-a)/float64(n-1)
+	}
+	// Правый конец задаем явно, чтобы избежать ошибок округления
+	xs[n-1] = b
+	return xs
+}
+
+// uniformNodes возвращает n+1 равноотстоящих узлов на [a, b]
+func uniformNodes(a, b float64, n int) []float64 {
+	h := (b - a) / float64(n)
+	nodes := make([]float64, n+1)
+
+	for i := 0; i <= n; i++ {
+		nodes[i] = a + float64(i)*h
+	}
+
+	return nodes
+}
+
+// chebyshevNodes возвращает n+1 узлов Чебышева (корней полинома T(n+1)) на [a, b]
+// в порядке возрастания
+func chebyshevNodes(a, b float64, n int) []float64 {
+	nodes := make([]float64, n+1)
 
 	for i := 0; i <= n; i++ {
 		// Узлы Чебышева на интервале [-1, 1]
-		ti := math.Cos(math.Pi * float64(2*i+1) / float64(2*(n+1)))
+		ti := -math.Cos(math.Pi * float64(2*i+1) / float64(2*(n+1)))
 
 		// Преобразование в интервал [a, b]
-		x := (a+b)/2 + (b-a)/2*ti
-		y := f(x)
-		points[i] = point{x: x, y: y}
+		nodes[i] = (a+b)/2 + (b-a)/2*ti
+	}
+
+	return nodes
+}
+
+// chebyshevNodes2 возвращает n+1 узлов Чебышева второго рода (точек экстремума T(n)) на [a, b]
+// по возрастанию; в отличие от chebyshevNodes концы интервала входят в число узлов
+func chebyshevNodes2(a, b float64, n int) []float64 {
+	nodes := make([]float64, n+1)
+
+	for i := 0; i <= n; i++ {
+		ti := -math.Cos(math.Pi * float64(i) / float64(n))
+		nodes[i] = (a+b)/2 + (b-a)/2*ti
+	}
+
+	// Концы задаем явно, чтобы избежать ошибок округления
+	nodes[0] = a
+	nodes[n] = b
+
+	return nodes
+}
+
+// logNodes возвращает n+1 узлов x(i) = a * (b/a)^(i/n) на [a, b], 0 < a < b:
+// отношение соседних узлов постоянно, узлы сгущаются к левому концу
+func logNodes(a, b float64, n int) []float64 {
+	nodes := make([]float64, n+1)
+	logRatio := math.Log(b / a)
+
+	for i := 0; i <= n; i++ {
+		nodes[i] = a * math.Exp(logRatio*float64(i)/float64(n))
+	}
+
+	// Концы задаем явно, чтобы избежать ошибок округления
+	nodes[0] = a
+	nodes[n] = b
+
+	return nodes
+}
+
+// createGrid создает равномерную сетку точек
+func createGrid(a, b float64, n int, f func(float64) float64) (*interpolationData, error) {
+	return createCustomGrid(a, b, n, f, uniformNodes)
+}
+
+// refineGrid возвращает равномерную сетку на том же [a, b] с вдвое большим количеством
+// отрезков: узлы data сохраняются, между ними добавляются середины, f вычисляется заново
+func refineGrid(data *interpolationData, f func(float64) float64) (*interpolationData, error) {
+	return createGrid(data.a, data.b, 2*data.n, f)
+}
+
+// createChebyshevGrid создает сетку точек на основе узлов Чебышева
+func createChebyshevGrid(a, b float64, n int, f func(float64) float64) (*interpolationData, error) {
+	return createCustomGrid(a, b, n, f, chebyshevNodes)
+}
+
+// createLogGrid создает сетку с геометрически возрастающими шагами (см. logNodes).
+// Подходит для функций, быстро меняющихся у левого конца отрезка; требует 0 < a < b
+func createLogGrid(a, b float64, n int, f func(float64) float64) (*interpolationData, error) {
+	if !(a > 0) || !(b > a) {
+		return nil, fmt.Errorf("логарифмическая сетка требует 0 < a < b, получено a = %g, b = %g", a, b)
+	}
+	if n < 1 {
+		return nil, fmt.Errorf("количество отрезков должно быть положительным, получено %d", n)
+	}
+	return createCustomGrid(a, b, n, f, logNodes)
+}
+
+// adaptiveGrid строит сетку, сгущающуюся там, где функция сильно искривлена: отрезок [a, b]
+// делится пополам, пока отклонение f от хорды в середине отрезка (погрешность линейной
+// интерполяции) больше tol. Первым делится отрезок с наибольшим отклонением, поэтому при
+// достижении maxNodes узлов сетка остается сбалансированной
+func adaptiveGrid(a, b float64, f func(float64) float64, maxNodes int, tol float64) *interpolationData {
+	points := []point{{x: a, y: f(a)}, {x: b, y: f(b)}}
+
+	// chordError возвращает середину отрезка [points[i], points[i+1]] и отклонение f от хорды в ней
+	chordError := func(i int) (point, float64) {
+		left, right := points[i], points[i+1]
+		mid := point{x: (left.x + right.x) / 2}
+		mid.y = f(mid.x)
+		return mid, math.Abs(mid.y - (left.y+right.y)/2)
+	}
+
+	mids := make([]point, 1)
+	errs := make([]float64, 1)
+	mids[0], errs[0] = chordError(0)
+
+	for len(points) < maxNodes {
+		worst := 0
+		for i := range errs {
+			if errs[i] > errs[worst] {
+				worst = i
+			}
+		}
+		if !(errs[worst] > tol) {
+			break
+		}
+
+		// Вставляем середину отрезка worst и пересчитываем отклонения двух новых отрезков
+		points = slices.Insert(points, worst+1, mids[worst])
+		mids = slices.Insert(mids, worst+1, point{})
+		errs = slices.Insert(errs, worst+1, 0)
+		mids[worst], errs[worst] = chordError(worst)
+		mids[worst+1], errs[worst+1] = chordError(worst + 1)
 	}
 
 	return &interpolationData{
 		points: points,
 		a:      a,
 		b:      b,
-		n:      n,
+		n:      len(points) - 1,
+	}
+}
+
+// createChebyshevGrid2 создает сетку точек на основе узлов Чебышева второго рода
+func createChebyshevGrid2(a, b float64, n int, f func(float64) float64) (*interpolationData, error) {
+	return createCustomGrid(a, b, n, f, chebyshevNodes2)
+}
+
+// addNoise возвращает копию сетки data, в которой к каждому значению y добавлен равномерный
+// шум из [-amplitude, amplitude]. Шум порождается генератором с зерном seed, поэтому
+// при одинаковом seed результат воспроизводим
+func addNoise(data *interpolationData, amplitude float64, seed int64) *interpolationData {
+	rng := rand.New(rand.NewSource(seed))
+
+	points := make([]point, len(data.points))
+	for i, p := range data.points {
+		points[i] = point{x: p.x, y: p.y + amplitude*(2*rng.Float64()-1)}
+	}
+
+	return &interpolationData{
+		points: points,
+		a:      data.a,
+		b:      data.b,
+		n:      data.n,
 	}
 }
 
@@ -111,8 +332,11 @@ func (m *matrix) get(i, j int) float64 {
 	return m.data[i][j]
 }
 
-// solveLinearSystem решает систему линейных уравнений Ax = b методом Гаусса
-func solveLinearSystem(a *matrix, b []float64) []float64 {
+// solveLinearSystem решает систему линейных уравнений Ax = b методом Гаусса с частичным
+// выбором ведущего элемента. Если ведущий элемент пренебрежимо мал, матрица считается
+// вырожденной и возвращается ошибка errSingularMatrix с номером столбца. В каждый
+// переданный verbose выводится норма невязки ||Ax - b|| найденного решения
+func solveLinearSystem(a *matrix, b []float64, verbose ...io.Writer) ([]float64, error) {
 	n := a.rows
 
 	// Создаем расширенную матрицу
@@ -126,15 +350,24 @@ func solveLinearSystem(a *matrix, b []float64) []float64 {
 
 	// Прямой ход метода Гаусса
 	for i := 0; i < n; i++ {
-		// Приведение к верхнетреугольному виду
+		// Выбираем ведущий элемент с максимальным модулем в столбце i
+		pivot := i
 		for k := i + 1; k < n; k++ {
-			if math.Abs(augmented.get(i, i)) < 1e-12 {
-				continue
+			if math.Abs(augmented.get(k, i)) > math.Abs(augmented.get(pivot, i)) {
+				pivot = k
 			}
+		}
+		if math.Abs(augmented.get(pivot, i)) < 1e-12 {
+			return nil, fmt.Errorf("%w: нулевой ведущий элемент в столбце %d", errSingularMatrix, i)
+		}
+		if pivot != i {
+			augmented.swapRows(i, pivot)
+		}
+
+		// Приведение к верхнетреугольному виду
+		for k := i + 1; k < n; k++ {
 			factor := augmented.get(k, i) / augmented.get(i, i)
-			for j := i; j <= n; j++ {
-				augmented.set(k, j, augmented.get(k, j)-factor*augmented.get(i, j))
-			}
+			augmented.addScaledRow(k, i, -factor)
 		}
 	}
 
@@ -145,14 +378,90 @@ func solveLinearSystem(a *matrix, b []float64) []float64 {
 		for j := i + 1; j < n; j++ {
 			solution[i] -= augmented.get(i, j) * solution[j]
 		}
-		if math.Abs(augmented.get(i, i)) > 1e-12 {
-			solution[i] /= augmented.get(i, i)
+		solution[i] /= augmented.get(i, i)
+	}
+
+	for _, w := range verbose {
+		fmt.Fprintf(w, "solveLinearSystem: n = %d, ||Ax - b|| = %.3e\n", n, residualNorm(a, solution, b))
+	}
+
+	return solution, nil
+}
+
+// solveTridiagonal решает трехдиагональную систему методом прогонки (алгоритм Томаса) за O(n).
+// lower[i] - коэффициент при x[i-1], diag[i] - при x[i], upper[i] - при x[i+1];
+// lower[0] и upper[n-1] не используются
+func solveTridiagonal(lower, diag, upper, rhs []float64) []float64 {
+	n := len(diag)
+	c := make([]float64, n)
+	d := make([]float64, n)
+
+	// Прямой ход: вычисляем прогоночные коэффициенты
+	c[0] = upper[0] / diag[0]
+	d[0] = rhs[0] / diag[0]
+	for i := 1; i < n; i++ {
+		denom := diag[i] - lower[i]*c[i-1]
+		if i < n-1 {
+			c[i] = upper[i] / denom
 		}
+		d[i] = (rhs[i] - lower[i]*d[i-1]) / denom
+	}
+
+	// Обратный ход
+	solution := make([]float64, n)
+	solution[n-1] = d[n-1]
+	for i := n - 2; i >= 0; i-- {
+		solution[i] = d[i] - c[i]*solution[i+1]
 	}
 
 	return solution
 }
 
+// solveCyclicTridiagonal решает циклическую трехдиагональную систему по формуле Шермана-Моррисона.
+// Угловые элементы: lower[0] - коэффициент при x[n-1] в первой строке,
+// upper[n-1] - коэффициент при x[0] в последней строке. Ошибка возможна только при n < 3,
+// когда система решается методом Гаусса
+func solveCyclicTridiagonal(lower, diag, upper, rhs []float64) ([]float64, error) {
+	n := len(diag)
+	if n < 3 {
+		// При n < 3 угловые элементы совпадают с обычными, решаем плотную систему
+		a := newMatrix(n, n)
+		for i := 0; i < n; i++ {
+			a.set(i, i, diag[i])
+		}
+		if n == 2 {
+			a.set(0, 1, upper[0]+lower[0])
+			a.set(1, 0, lower[1]+upper[1])
+		}
+		return solveLinearSystem(a, rhs)
+	}
+
+	alpha := upper[n-1]
+	beta := lower[0]
+	gamma := -diag[0]
+
+	// Модифицированная трехдиагональная матрица A' = A - u*v^T
+	modDiag := make([]float64, n)
+	copy(modDiag, diag)
+	modDiag[0] = diag[0] - gamma
+	modDiag[n-1] = diag[n-1] - alpha*beta/gamma
+
+	x := solveTridiagonal(lower, modDiag, upper, rhs)
+
+	u := make([]float64, n)
+	u[0] = gamma
+	u[n-1] = alpha
+	z := solveTridiagonal(lower, modDiag, upper, u)
+
+	// Поправка Шермана-Моррисона
+	factor := (x[0] + beta*x[n-1]/gamma) / (1 + z[0] + beta*z[n-1]/gamma)
+	for i := 0; i < n; i++ {
+		x[i] -= factor * z[i]
+	}
+
+	return x, nil
+}
+
 // cubicSpline представляет кубический сплайн с прямым вычислением по формуле
 type cubicSpline struct {
 	points            []point
@@ -160,9 +469,16 @@ type cubicSpline struct {
 	h                 []float64
 }
 
-// newCubicSpline создает кубический сплайн с естественными граничными условиями
-func newCubicSpline(data *interpolationData) *cubicSpline {
-	points := data.points
+// splineSystem содержит трехдиагональную систему для вторых производных кубического сплайна
+type splineSystem struct {
+	lower, diag, upper []float64 // Диагонали матрицы
+	rhs                []float64 // Правая часть
+	h                  []float64 // Шаги h[i] = x[i+1] - x[i]
+}
+
+// newSplineSystem заполняет уравнения для внутренних узлов; первая и последняя строки
+// задаются граничными условиями конкретного сплайна
+func newSplineSystem(points []point) *splineSystem {
 	n := len(points)
 
 	// Извлекаем x и y координаты
@@ -179,47 +495,109 @@ func newCubicSpline(data *interpolationData) *cubicSpline {
 		h[i] = x[i+1] - x[i]
 	}
 
-	// Создаем матрицу a и вектор b для системы уравнений
-	a := newMatrix(n, n)
-	b := make([]float64, n)
+	// Система трехдиагональная: храним только три диагонали и правую часть
+	sys := &splineSystem{
+		lower: make([]float64, n),
+		diag:  make([]float64, n),
+		upper: make([]float64, n),
+		rhs:   make([]float64, n),
+		h:     h,
+	}
 
 	// Заполняем систему уравнений для внутренних точек
 	for i := 1; i < n-1; i++ {
-		a.set(i, i-1, h[i-1])
-		a.set(i, i, 2*(h[i-1]+h[i]))
-		a.set(i, i+1, h[i])
-		b[i] = 6 * ((y[i+1]-y[i])/h[i] - (y[i]-y[i-1])/h[i-1])
+		sys.lower[i] = h[i-1]
+		sys.diag[i] = 2 * (h[i-1] + h[i])
+		sys.upper[i] = h[i]
+		sys.rhs[i] = 6 * ((y[i+1]-y[i])/h[i] - (y[i]-y[i-1])/h[i-1])
 	}
 
-	// Граничные условия для естественного сплайна (вторые производные на концах равны нулю)
-	a.set(0, 0, 1)
-	a.set(n-1, n-1, 1)
-	b[0] = 0
-	b[n-1] = 0
-
-	// Решаем систему для вторых производных
-	secondDerivatives := solveLinearSystem(a, b)
+	return sys
+}
 
+// solve решает систему методом прогонки и собирает сплайн
+func (sys *splineSystem) solve(points []point) *cubicSpline {
 	return &cubicSpline{
 		points:            points,
-		secondDerivatives: secondDerivatives,
-		h:                 h,
+		secondDerivatives: solveTridiagonal(sys.lower, sys.diag, sys.upper, sys.rhs),
+		h:                 sys.h,
 	}
 }
 
-// Evaluate вычисляет значение сплайна в точке x по формуле (2.61)
-func (cs *cubicSpline) evaluate(x float64) float64 {
-	n := len(cs.points)
+// newCubicSpline создает кубический сплайн с естественными граничными условиями
+func newCubicSpline(data *interpolationData) (*cubicSpline, error) {
+	points := data.points
+	if err := validateSplinePoints(points); err != nil {
+		return nil, err
+	}
+	n := len(points)
+	sys := newSplineSystem(points)
 
-	// Находим интервал, содержащий точку x
-	i := 0
-	for i < n-1 {
-		if x >= cs.points[i].x && x <= cs.points[i+1].x {
-			break
+	// Граничные условия для естественного сплайна (вторые производные на концах равны нулю)
+	sys.diag[0] = 1
+	sys.diag[n-1] = 1
+	sys.rhs[0] = 0
+	sys.rhs[n-1] = 0
+
+	// Решаем систему для вторых производных методом прогонки
+	return sys.solve(points), nil
+}
+
+// validateSplinePoints проверяет, что узлов не меньше двух и их абсциссы строго возрастают.
+// По двум узлам сплайны вырождаются в отрезок прямой
+func validateSplinePoints(points []point) error {
+	if len(points) < 2 {
+		return fmt.Errorf("для построения нужно не меньше 2 узлов, получено %d", len(points))
+	}
+	return validatePoints(points)
+}
+
+// validatePoints проверяет, что абсциссы узлов строго возрастают. Повторяющиеся узлы
+// дают деление на ноль в полиноме Лагранжа и нулевые шаги h в сплайнах
+func validatePoints(points []point) error {
+	for i := 1; i < len(points); i++ {
+		prev, cur := points[i-1].x, points[i].x
+		switch {
+		case cur == prev:
+			return fmt.Errorf("повторяющийся узел x = %g (номера %d и %d)", cur, i-1, i)
+		case !(cur > prev):
+			return fmt.Errorf("узлы должны строго возрастать по x: x[%d] = %g, x[%d] = %g", i-1, prev, i, cur)
 		}
-		i++
 	}
+	return nil
+}
 
+// findInterval бинарным поиском находит номер отрезка [x(i), x(i+1)], содержащего x.
+// Узлы должны быть упорядочены по возрастанию x. Для x в узле выбирается левый отрезок
+// (как при последовательном просмотре), для x вне [x0, xn] - ближайший крайний отрезок
+func findInterval(points []point, x float64) int {
+	n := len(points)
+	i := sort.Search(n-1, func(j int) bool {
+		return x <= points[j+1].x
+	})
+	if i > n-2 {
+		i = n - 2
+	}
+	return i
+}
+
+// Evaluate вычисляет значение сплайна в точке x по формуле (2.61).
+// Вне интервала [x0, xn] сплайн продолжается полиномом ближайшего крайнего отрезка
+func (cs *cubicSpline) evaluate(x float64) float64 {
+	// Вырожденный сплайн, созданный в обход конструктора
+	switch len(cs.points) {
+	case 0:
+		return math.NaN()
+	case 1:
+		return cs.points[0].y
+	}
+
+	// Находим интервал, содержащий точку x
+	return cs.evaluateOn(findInterval(cs.points, x), x)
+}
+
+// evaluateOn вычисляет в точке x полином сплайна на отрезке i
+func (cs *cubicSpline) evaluateOn(i int, x float64) float64 {
 	// формула (2.61)
 	xi := cs.points[i].x
 	xi1 := cs.points[i+1].x
@@ -241,106 +619,395 @@ func (cs *cubicSpline) evaluate(x float64) float64 {
 	return term1 + term2 + term3 + term4
 }
 
+// errExtrapolation сообщает, что значение вычислено вне интервала интерполяции
+var errExtrapolation = errors.New("экстраполяция за пределы интервала интерполяции")
+
+// evaluateChecked вычисляет значение сплайна в точке x и возвращает ошибку errExtrapolation,
+// если x лежит вне [x0, xn]. Значение при этом все равно вычисляется по крайнему отрезку
+func (cs *cubicSpline) evaluateChecked(x float64) (float64, error) {
+	value := cs.evaluate(x)
+	first := cs.points[0].x
+	last := cs.points[len(cs.points)-1].x
+	if x < first || x > last {
+		return value, fmt.Errorf("%w: x = %g вне [%g, %g]", errExtrapolation, x, first, last)
+	}
+	return value, nil
+}
+
+// TableFormat задает формат чисел в таблицах printTable и compareInterpolations.
+// Абсциссы x всегда выводятся с 4 знаками, ошибки - в экспоненциальной форме
+type TableFormat struct {
+	Precision  int  // Количество знаков после запятой в значениях и ошибках
+	Scientific bool // Выводить значения функции и методов в экспоненциальной форме
+	Width      int  // Ширина числовых столбцов; 0 - ширина по умолчанию для каждого столбца
+}
+
+// defaultTableFormat возвращает формат по умолчанию: 6 знаков, фиксированная точка
+func defaultTableFormat() TableFormat {
+	return TableFormat{Precision: 6}
+}
+
+// width возвращает ширину столбца с шириной по умолчанию def
+func (tf TableFormat) width(def int) int {
+	if tf.Width > 0 {
+		return tf.Width
+	}
+	return def
+}
+
+// value форматирует значение функции или метода в столбце шириной по умолчанию def
+func (tf TableFormat) value(v float64, def int) string {
+	verb := byte('f')
+	if tf.Scientific {
+		verb = 'e'
+	}
+	return fmt.Sprintf("%-*s", tf.width(def), strconv.FormatFloat(v, verb, tf.Precision, 64))
+}
+
+// error форматирует ошибку в экспоненциальной форме в столбце шириной по умолчанию def
+func (tf TableFormat) error(v float64, def int) string {
+	return fmt.Sprintf("%-*.*e", tf.width(def), tf.Precision, v)
+}
+
+// header выравнивает заголовок по ширине столбца
+func (tf TableFormat) header(title string, def int) string {
+	return fmt.Sprintf("%-*s", tf.width(def), title)
+}
+
 // printTable выводит таблицу исходных данных
-func printTable(data *interpolationData, title string) {
+func printTable(data *interpolationData, title string, format TableFormat) {
 	fmt.Printf("Таблица исходных данных (%s):\n", title)
-	fmt.Printf("%-10s %-15s\n", "xi", "f(xi)")
-	fmt.Println(strings.Repeat("-", 25))
+	fmt.Printf("%-10s %s\n", "xi", format.header("f(xi)", 15))
+	fmt.Println(strings.Repeat("-", 10+format.width(15)))
 
 	for _, point := range data.points {
-		fmt.Printf("%-10.4f %-15.6f\n", point.x, point.y)
+		fmt.Printf("%-10.4f %s\n", point.x, format.value(point.y, 15))
 	}
 	fmt.Println()
 }
 
-// compareInterpolations сравнивает методы интерполяции
-func compareInterpolations(uniformData, chebyshevData *interpolationData, testFunc func(float64) float64) {
-	fmt.Println("Сравнение методов интерполяции:")
-	fmt.Printf("%-10s %-12s %-12s %-12s %-12s %-12s %-12s %-12s\n",
-		"x", "f(x)", "пол Лагр", "Ош Лагр", "узлы Чеб", "Ош Чеб", "Сплайн", "Ош Спл")
-	fmt.Println(strings.Repeat("-", 110))
+// comparisonRow - строка таблицы сравнения: точное значение и значения методов в точке x
+type comparisonRow struct {
+	x      float64
+	exact  float64
+	values []float64 // Значения методов в порядке comparisonResult.names
+}
 
-	spline := newCubicSpline(uniformData)
+// methodErrors - сводные ошибки одного метода на выборке из 100 равноотстоящих точек
+type methodErrors struct {
+	name      string
+	maxErr    float64
+	rms       float64
+	l2        float64       // L2-норма ошибки на [a, b]
+	p50       float64       // Медиана ошибки
+	p90       float64       // 90-й процентиль ошибки
+	p99       float64       // 99-й процентиль ошибки
+	buildTime time.Duration // Время построения метода (0, если метод не требует построения)
+	evalTime  time.Duration // Время вычисления метода во всех точках выборки
+}
 
-	for i := 0; i < 20; i++ {
-		x := uniformData.a + float64(i)*(uniformData.b-uniformData.a)/19.0
+// comparisonResult - результат сравнения методов интерполяции
+type comparisonResult struct {
+	names  []string
+	rows   []comparisonRow
+	errors []methodErrors
+}
 
-		original := testFunc(x)
-		lagrangeUniform := lagrangeInterpolation(uniformData, x)
-		lagrangeChebyshev := lagrangeInterpolation(chebyshevData, x)
-		splineVal := spline.evaluate(x)
+// compareInterpolations сравнивает методы интерполяции на интервале [a, b], выводит
+// таблицу значений и сводку ошибок и возвращает их для дальнейшей обработки
+func compareInterpolations(methods []Interpolator, a, b float64, testFunc func(float64) float64, format TableFormat) *comparisonResult {
+	result := &comparisonResult{}
+	for _, m := range methods {
+		result.names = append(result.names, m.Name())
+	}
 
-		errorUniform := math.Abs(original - lagrangeUniform)
-		errorChebyshev := math.Abs(original - lagrangeChebyshev)
-		errorSpline := math.Abs(original - splineVal)
+	for _, x := range linspace(a, b, 20) {
+		row := comparisonRow{x: x, exact: testFunc(x), values: make([]float64, len(methods))}
+		for k, m := range methods {
+			row.values[k] = m.Evaluate(x)
+		}
+		result.rows = append(result.rows, row)
+	}
 
-		fmt.Printf("%-10.4f %-12.6f %-12.6f %-12.6e %-12.6f %-12.6e %-12.6f %-12.6e\n",
-			x, original, lagrangeUniform, errorUniform, lagrangeChebyshev, errorChebyshev, splineVal, errorSpline)
+	const samples = 100
+	xs := linspace(a, b, samples)
+	originals := make([]float64, samples)
+	for i, x := range xs {
+		originals[i] = testFunc(x)
 	}
-	fmt.Println()
 
-	maxErrorUniform := 0.0
-	maxErrorChebyshev := 0.0
-	maxErrorSpline := 0.0
+	// Максимум, СКО и L2-норма накапливаются в runningStats по мере вычисления. Процентили
+	// потоково не вычисляются: для них ошибки всех точек хранятся и сортируются, поэтому
+	// память растет как O(samples) - буфер errs один на все методы. Методы обходятся
+	// по одному, чтобы измерить время вычисления каждого
+	h := (b - a) / (samples - 1)
+	errs := make([]float64, samples)
+	for k, m := range methods {
+		var stats runningStats
+
+		start := time.Now()
+		for i, x := range xs {
+			e := math.Abs(originals[i] - m.Evaluate(x))
+			stats.add(e)
+			errs[i] = e
+		}
+		evalTime := time.Since(start)
 
-	for i := 0; i < 100; i++ {
-		x := uniformData.a + float64(i)*(uniformData.b-uniformData.a)/99.0
-		original := testFunc(x)
+		var buildTime time.Duration
+		if timed, ok := m.(timedInterpolator); ok {
+			buildTime = timed.BuildTime()
+		}
 
-		errorUniform := math.Abs(original - lagrangeInterpolation(uniformData, x))
-		errorChebyshev := math.Abs(original - lagrangeInterpolation(chebyshevData, x))
-		errorSpline := math.Abs(original - spline.evaluate(x))
+		sort.Float64s(errs)
+		result.errors = append(result.errors, methodErrors{
+			name:      result.names[k],
+			maxErr:    stats.max(),
+			rms:       stats.rms(),
+			l2:        stats.l2(h),
+			p50:       percentile(errs, 50),
+			p90:       percentile(errs, 90),
+			p99:       percentile(errs, 99),
+			buildTime: buildTime,
+			evalTime:  evalTime,
+		})
+	}
 
-		if errorUniform > maxErrorUniform {
-			maxErrorUniform = errorUniform
+	result.print(format)
+	return result
+}
+
+// print выводит таблицу значений и сводку ошибок методов в формате format
+func (r *comparisonResult) print(format TableFormat) {
+	fmt.Println("Сравнение методов интерполяции:")
+	fmt.Printf("%-10s %s", "x", format.header("f(x)", 12))
+	for _, name := range r.names {
+		fmt.Printf(" %s %s %s", format.header(name, 14), format.header("ошибка", 12), format.header("отн. ошибка", 12))
+	}
+	fmt.Println()
+	fmt.Println(strings.Repeat("-", 11+format.width(12)+(format.width(14)+2*format.width(12)+3)*len(r.names)))
+
+	for _, row := range r.rows {
+		fmt.Printf("%-10.4f %s", row.x, format.value(row.exact, 12))
+		for _, value := range row.values {
+			fmt.Printf(" %s %s %s", format.value(value, 14),
+				format.error(math.Abs(row.exact-value), 12), format.error(relativeError(row.exact, value), 12))
 		}
-		if errorChebyshev > maxErrorChebyshev {
-			maxErrorChebyshev = errorChebyshev
+		fmt.Println()
+	}
+	fmt.Println()
+
+	fmt.Println("Ошибки методов:")
+	fmt.Printf("  %-28s %s %s %s %s %s %s %-14s %s\n", "Метод", format.header("Максимальная", 14), format.header("СКО", 14),
+		format.header("L2-норма", 14), format.header("Медиана", 14), format.header("P90", 14), format.header("P99", 14),
+		"Построение", "Вычисление")
+	for _, e := range r.errors {
+		fmt.Printf("  %-28s %s %s %s %s %s %s %-14v %v\n", e.name+":", format.error(e.maxErr, 14), format.error(e.rms, 14),
+			format.error(e.l2, 14), format.error(e.p50, 14), format.error(e.p90, 14), format.error(e.p99, 14),
+			e.buildTime, e.evalTime)
+	}
+	fmt.Println()
+}
+
+// functions содержит тестовые функции, доступные для выбора флагом -func
+var functions = map[string]func(float64) float64{
+	"test":   testFunction,
+	"module": moduleFunction,
+	"runge":  rungeFunction,
+}
+
+// functionByName возвращает тестовую функцию по ее имени
+func functionByName(name string) (func(float64) float64, error) {
+	f, ok := functions[name]
+	if !ok {
+		return nil, fmt.Errorf("неизвестная функция %q, доступны: %s", name, strings.Join(functionNames(), ", "))
+	}
+	return f, nil
+}
+
+// resolveFunction возвращает тестовую функцию по имени, а если такого имени нет -
+// разбирает spec как выражение от x
+func resolveFunction(spec string) (func(float64) float64, error) {
+	if f, err := functionByName(spec); err == nil {
+		return f, nil
+	}
+
+	f, err := parseFunction(spec)
+	if err != nil {
+		return nil, fmt.Errorf("%q не является именем функции (%s) или корректным выражением: %w",
+			spec, strings.Join(functionNames(), ", "), err)
+	}
+	return f, nil
+}
+
+// functionNames возвращает отсортированный список имен тестовых функций
+func functionNames() []string {
+	names := make([]string, 0, len(functions))
+	for name := range functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseNodeCounts разбирает список количеств узлов, разделенных запятыми
+func parseNodeCounts(s string) ([]int, error) {
+	var counts []int
+	for _, field := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			return nil, fmt.Errorf("некорректное количество узлов %q", field)
 		}
-		if errorSpline > maxErrorSpline {
-			maxErrorSpline = errorSpline
+		if n < 1 {
+			return nil, fmt.Errorf("количество узлов должно быть не меньше 1, получено %d", n)
 		}
+		counts = append(counts, n)
 	}
+	return counts, nil
+}
 
-	fmt.Println("Максимальные ошибки:")
-	fmt.Printf("  Лагранж (равномерные узлы): %.6e\n", maxErrorUniform)
-	fmt.Printf("  Лагранж (узлы Чебышева):    %.6e\n", maxErrorChebyshev)
-	fmt.Printf("  Кубический сплайн:          %.6e\n", maxErrorSpline)
-	fmt.Println()
+// usageError выводит сообщение об ошибке и справку по флагам fs, затем завершает программу
+func usageError(fs *flag.FlagSet, err error) {
+	fmt.Fprintf(os.Stderr, "Ошибка: %v\n\n", err)
+	fs.Usage()
+	os.Exit(2)
 }
 
-func main() {
-	fmt.Printf("=== Лабораторная работа №1: Интерполяция ===\n")
+// runInterp выполняет подкоманду interp: интерполяцию функции на сетках с разным количеством
+// узлов, сравнение методов, отчеты и исследование сходимости
+func runInterp(args []string) error {
+	fs := flag.NewFlagSet("interp", flag.ExitOnError)
 
 	// Параметры для интерполяции
-	a, b := 1.0, 5.0
+	aFlag := fs.Float64("a", 1.0, "левая граница интервала")
+	bFlag := fs.Float64("b", 5.0, "правая граница интервала")
+	nFlag := fs.String("n", "10", "количество узлов (список через запятую)")
+	defaultOpts := defaultHTMLOptions()
+	pointsFlag := fs.Int("points", defaultOpts.numPoints, "количество точек на графиках HTML отчета")
+	errChartFlag := fs.Bool("errchart", defaultOpts.showErrorChart, "добавлять в HTML отчет график ошибок")
+	convFlag := fs.String("conv", "5,10,20,40", "количества узлов для исследования сходимости (пусто - не проводить)")
+	defaultFormat := defaultTableFormat()
+	precFlag := fs.Int("prec", defaultFormat.Precision, "количество знаков после запятой в таблицах")
+	sciFlag := fs.Bool("sci", defaultFormat.Scientific, "выводить значения в таблицах в экспоненциальной форме")
+	widthFlag := fs.Int("width", defaultFormat.Width, "ширина числовых столбцов таблиц (0 - по умолчанию)")
+	funcFlag := fs.String("func", "test",
+		"интерполируемая функция: "+strings.Join(functionNames(), ", ")+" или выражение от x, например \"sin(x)^2\"")
+	fs.Parse(args)
+
+	a, b := *aFlag, *bFlag
+	if a >= b {
+		usageError(fs, fmt.Errorf("левая граница a = %g должна быть меньше правой b = %g", a, b))
+	}
 
 	// Тестирование с разным количеством узлов
-	nValues := []int{10}
+	nValues, err := parseNodeCounts(*nFlag)
+	if err != nil {
+		usageError(fs, err)
+	}
+
+	if *pointsFlag < 2 {
+		usageError(fs, fmt.Errorf("количество точек графика должно быть не меньше 2, получено %d", *pointsFlag))
+	}
+	htmlOpts := htmlOptions{numPoints: *pointsFlag, showErrorChart: *errChartFlag}
+
+	if *precFlag < 0 || *widthFlag < 0 {
+		usageError(fs, fmt.Errorf("точность и ширина столбцов должны быть неотрицательными, получено %d и %d", *precFlag, *widthFlag))
+	}
+	tableFormat := TableFormat{Precision: *precFlag, Scientific: *sciFlag, Width: *widthFlag}
+
+	var convValues []int
+	if *convFlag != "" {
+		convValues, err = parseNodeCounts(*convFlag)
+		if err != nil {
+			usageError(fs, err)
+		}
+	}
+
+	f, err := resolveFunction(*funcFlag)
+	if err != nil {
+		usageError(fs, err)
+	}
+
+	fmt.Printf("=== Лабораторная работа №1: Интерполяция ===\n")
 
 	for _, n := range nValues {
 		fmt.Printf("\n=== Тестирование с N = %d узлами ===\n\n", n)
 
 		// Создаем равномерную сетку
-		uniformData := createGrid(a, b, n, testFunction)
-		printTable(uniformData, "равномерные узлы")
+		uniformData, err := createGrid(a, b, n, f)
+		if err != nil {
+			return fmt.Errorf("N = %d: %w", n, err)
+		}
+		printTable(uniformData, "равномерные узлы", tableFormat)
 
 		// Создаем сетку Чебышева
-		chebyshevData := createChebyshevGrid(a, b, n, testFunction)
-		printTable(chebyshevData, "узлы Чебышева")
+		chebyshevData, err := createChebyshevGrid(a, b, n, f)
+		if err != nil {
+			return fmt.Errorf("N = %d: %w", n, err)
+		}
+		printTable(chebyshevData, "узлы Чебышева", tableFormat)
+
+		// Создаем сетку Чебышева второго рода (с концами интервала)
+		chebyshev2Data, err := createChebyshevGrid2(a, b, n, f)
+		if err != nil {
+			return fmt.Errorf("N = %d: %w", n, err)
+		}
 
 		// Сравниваем методы интерполяции
-		compareInterpolations(uniformData, chebyshevData, testFunction)
+		methods, err := defaultInterpolators(uniformData, chebyshevData, chebyshev2Data)
+		if err != nil {
+			return fmt.Errorf("построение интерполянтов: %w", err)
+		}
+		compareInterpolations(methods, a, b, f, tableFormat)
+
+		// Сравниваем интеграл функции и интеграл интерполянта
+		compareQuadratures(uniformData, f)
 
 		// Генерируем HTML файл с графиками
 		filename := fmt.Sprintf("interpolation_n%d.html", n)
-		err := generateHTML(uniformData, chebyshevData, testFunction, filename)
+		err = generateHTML(uniformData, chebyshevData, f, filename, htmlOpts)
 		if err != nil {
 			fmt.Printf("Ошибка при создании HTML файла: %v\n", err)
 		} else {
-			fmt.Printf("✓ График сохранен в файл: %s\n\n", filename)
+			fmt.Printf("✓ График сохранен в файл: %s\n", filename)
+		}
+
+		// Сохраняем таблицу результатов в CSV
+		csvFilename := fmt.Sprintf("interpolation_n%d.csv", n)
+		err = exportResultsCSV(csvFilename, uniformData, f, 201)
+		if err != nil {
+			fmt.Printf("Ошибка при создании CSV файла: %v\n\n", err)
+		} else {
+			fmt.Printf("✓ Результаты сохранены в файл: %s\n", csvFilename)
+		}
+
+		// Сохраняем полный набор результатов в JSON
+		jsonFilename := fmt.Sprintf("interpolation_n%d.json", n)
+		grids := map[string]*interpolationData{
+			"uniform":    uniformData,
+			"chebyshev":  chebyshevData,
+			"chebyshev2": chebyshev2Data,
+		}
+		err = exportResultsJSON(jsonFilename, a, b, grids, methods, f, 201)
+		if err != nil {
+			fmt.Printf("Ошибка при создании JSON файла: %v\n\n", err)
+		} else {
+			fmt.Printf("✓ Результаты сохранены в файл: %s\n\n", jsonFilename)
+		}
+	}
+
+	if len(convValues) > 0 {
+		// Ctrl-C прерывает исследование сходимости после текущего N, а не всю программу
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		entries, err := convergenceStudy(ctx, a, b, convValues, f)
+		stop()
+		if len(entries) > 0 {
+			printConvergenceStudy(entries)
+		}
+		if err != nil {
+			fmt.Printf("Ошибка при исследовании сходимости: %v\n", err)
 		}
 	}
 
 	fmt.Println("Все графики созданы! Откройте HTML файлы в браузере для просмотра.")
+	return nil
 }
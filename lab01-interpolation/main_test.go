@@ -0,0 +1,711 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// naturalSplineSystem возвращает трехдиагональную систему естественного сплайна по узлам data
+func naturalSplineSystem(t testing.TB, data *interpolationData) *splineSystem {
+	t.Helper()
+	if err := validateSplinePoints(data.points); err != nil {
+		t.Fatal(err)
+	}
+	n := len(data.points)
+	sys := newSplineSystem(data.points)
+	sys.diag[0] = 1
+	sys.diag[n-1] = 1
+	return sys
+}
+
+// denseMatrix собирает из трех диагоналей плотную матрицу для solveLinearSystem
+func denseMatrix(lower, diag, upper []float64) *matrix {
+	n := len(diag)
+	a := newMatrix(n, n)
+	for i := 0; i < n; i++ {
+		a.set(i, i, diag[i])
+		if i > 0 {
+			a.set(i, i-1, lower[i])
+		}
+		if i < n-1 {
+			a.set(i, i+1, upper[i])
+		}
+	}
+	return a
+}
+
+func TestSolveTridiagonalMatchesGauss(t *testing.T) {
+	grids := []struct {
+		name string
+		make func(n int) (*interpolationData, error)
+	}{
+		{"равномерная", func(n int) (*interpolationData, error) { return createGrid(1, 5, n, testFunction) }},
+		{"Чебышев", func(n int) (*interpolationData, error) { return createChebyshevGrid(1, 5, n, testFunction) }},
+		{"модуль", func(n int) (*interpolationData, error) { return createGrid(-1, 1, n, moduleFunction) }},
+	}
+
+	for _, g := range grids {
+		for _, n := range []int{2, 5, 10, 20, 40} {
+			data, err := g.make(n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sys := naturalSplineSystem(t, data)
+
+			thomas := solveTridiagonal(sys.lower, sys.diag, sys.upper, sys.rhs)
+			gauss, err := solveLinearSystem(denseMatrix(sys.lower, sys.diag, sys.upper), sys.rhs)
+			if err != nil {
+				t.Fatalf("%s, N = %d: %v", g.name, n, err)
+			}
+			for i := range thomas {
+				if diff := math.Abs(thomas[i] - gauss[i]); diff > 1e-10 {
+					t.Errorf("%s, N = %d: M[%d] прогонкой %g, методом Гаусса %g (разница %.3e)",
+						g.name, n, i, thomas[i], gauss[i], diff)
+				}
+			}
+		}
+	}
+}
+
+// benchmarkSystemSize - количество узлов сплайна в бенчмарках решения системы
+const benchmarkSystemSize = 1000
+
+// BenchmarkSolveTridiagonal и BenchmarkSolveLinearSystem решают одну и ту же систему
+// естественного сплайна по 1000 узлам. Прогонка выполняется за O(n), метод Гаусса -
+// за O(n^3): на этом размере прогонка занимает порядка 15 мкс, метод Гаусса - порядка 0,7 с
+func BenchmarkSolveTridiagonal(b *testing.B) {
+	data, err := createGrid(1, 5, benchmarkSystemSize-1, testFunction)
+	if err != nil {
+		b.Fatal(err)
+	}
+	sys := naturalSplineSystem(b, data)
+
+	for b.Loop() {
+		solveTridiagonal(sys.lower, sys.diag, sys.upper, sys.rhs)
+	}
+}
+
+func BenchmarkSolveLinearSystem(b *testing.B) {
+	data, err := createGrid(1, 5, benchmarkSystemSize-1, testFunction)
+	if err != nil {
+		b.Fatal(err)
+	}
+	sys := naturalSplineSystem(b, data)
+	a := denseMatrix(sys.lower, sys.diag, sys.upper)
+
+	for b.Loop() {
+		if _, err := solveLinearSystem(a, sys.rhs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// linearInterval - прежний последовательный поиск отрезка, с которым сравнивается findInterval.
+// Для x вне [x0, xn] он не определен
+func linearInterval(points []point, x float64) int {
+	i := 0
+	for i < len(points)-1 {
+		if x >= points[i].x && x <= points[i+1].x {
+			break
+		}
+		i++
+	}
+	return i
+}
+
+func TestFindIntervalMatchesLinearScan(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 7, 100} {
+		data, err := createChebyshevGrid(1, 5, n, testFunction)
+		if err != nil {
+			t.Fatal(err)
+		}
+		points := data.points
+		lo, hi := points[0].x, points[len(points)-1].x
+
+		// Случайные точки между крайними узлами и сами узлы, для которых выбирается левый отрезок
+		queries := make([]float64, 0, 1000+len(points))
+		for range 1000 {
+			queries = append(queries, lo+rng.Float64()*(hi-lo))
+		}
+		for _, p := range points {
+			queries = append(queries, p.x)
+		}
+
+		for _, x := range queries {
+			if got, want := findInterval(points, x), linearInterval(points, x); got != want {
+				t.Errorf("N = %d, x = %g: findInterval = %d, последовательный поиск = %d", n, x, got, want)
+			}
+		}
+	}
+}
+
+// benchmarkSplineSize - количество узлов сплайна в бенчмарках вычисления
+const benchmarkSplineSize = 500
+
+// benchmarkSplineQueries возвращает сплайн по benchmarkSplineSize узлам и точки, в которых
+// он вычисляется: равномерный проход по отрезку, как при построении графиков
+func benchmarkSplineQueries(b *testing.B) (*cubicSpline, []float64) {
+	data, err := createGrid(1, 5, benchmarkSplineSize-1, testFunction)
+	if err != nil {
+		b.Fatal(err)
+	}
+	spline, err := newCubicSpline(data)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return spline, linspace(data.a, data.b, 200)
+}
+
+// BenchmarkSplineEvaluate и BenchmarkSplineEvaluateLinearScan вычисляют сплайн по 500 узлам
+// в 200 точках с бинарным и с прежним последовательным поиском отрезка: на проход уходит
+// порядка 3,6 мкс против 85 мкс, бинарный поиск быстрее примерно в 20 раз
+func BenchmarkSplineEvaluate(b *testing.B) {
+	spline, xs := benchmarkSplineQueries(b)
+	for b.Loop() {
+		for _, x := range xs {
+			spline.evaluate(x)
+		}
+	}
+}
+
+func BenchmarkSplineEvaluateLinearScan(b *testing.B) {
+	spline, xs := benchmarkSplineQueries(b)
+	for b.Loop() {
+		for _, x := range xs {
+			spline.evaluateOn(linearInterval(spline.points, x), x)
+		}
+	}
+}
+
+func TestEvaluateCheckedOutOfRange(t *testing.T) {
+	data, err := createGrid(1, 5, 8, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spline, err := newCubicSpline(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := len(spline.points) - 2
+
+	for _, tc := range []struct {
+		x        float64
+		interval int
+		outside  bool
+	}{
+		{data.a - 1, 0, true},
+		{data.a, 0, false},
+		{3.3, findInterval(spline.points, 3.3), false},
+		{data.b, last, false},
+		{data.b + 1, last, true},
+	} {
+		value, err := spline.evaluateChecked(tc.x)
+		if outside := errors.Is(err, errExtrapolation); outside != tc.outside || (err != nil && !outside) {
+			t.Errorf("x = %g: ошибка %v, ожидалась экстраполяция: %v", tc.x, err, tc.outside)
+		}
+		// Вне отрезка значение продолжается полиномом крайнего отрезка
+		if want := spline.evaluateOn(tc.interval, tc.x); value != want {
+			t.Errorf("x = %g: значение %g, ожидалось %g", tc.x, value, want)
+		}
+	}
+}
+
+func TestFunctionByName(t *testing.T) {
+	for name, want := range map[string]func(float64) float64{
+		"test":   testFunction,
+		"module": moduleFunction,
+		"runge":  rungeFunction,
+	} {
+		f, err := functionByName(name)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for _, x := range []float64{-0.5, 0.3, 2} {
+			if f(x) != want(x) {
+				t.Errorf("%s(%g) = %g, ожидалось %g", name, x, f(x), want(x))
+			}
+		}
+	}
+
+	if _, err := functionByName("sin"); err == nil {
+		t.Error("ожидалась ошибка для неизвестного имени функции")
+	}
+}
+
+func TestParseNodeCounts(t *testing.T) {
+	counts, err := parseNodeCounts("5, 10,20")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{5, 10, 20}; !slices.Equal(counts, want) {
+		t.Errorf("parseNodeCounts = %v, ожидалось %v", counts, want)
+	}
+
+	for _, s := range []string{"", "10,", "abc", "0", "5,-1"} {
+		if _, err := parseNodeCounts(s); err == nil {
+			t.Errorf("parseNodeCounts(%q): ожидалась ошибка", s)
+		}
+	}
+}
+
+func TestCreateCustomGridUniform(t *testing.T) {
+	uniform := func(a, b float64, n int) []float64 {
+		nodes := make([]float64, n+1)
+		for i := range nodes {
+			nodes[i] = a + float64(i)*((b-a)/float64(n))
+		}
+		return nodes
+	}
+
+	custom, err := createCustomGrid(1, 5, 10, testFunction, uniform)
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid, err := createGrid(1, 5, 10, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if custom.a != grid.a || custom.b != grid.b || custom.n != grid.n || !slices.Equal(custom.points, grid.points) {
+		t.Errorf("createCustomGrid = %+v, createGrid = %+v", custom, grid)
+	}
+
+	// Функция не определена в части узлов
+	if _, err := createCustomGrid(-1, 1, 4, math.Log, uniform); err == nil {
+		t.Error("ожидалась ошибка для бесконечного значения функции в узле")
+	}
+}
+
+func TestChebyshevGrid2IncludesEndpoints(t *testing.T) {
+	for _, n := range []int{1, 2, 7, 30} {
+		data, err := createChebyshevGrid2(1, 5, n, testFunction)
+		if err != nil {
+			t.Fatal(err)
+		}
+		points := data.points
+		if len(points) != n+1 {
+			t.Fatalf("N = %d: %d узлов", n, len(points))
+		}
+		if points[0].x != 1 || points[n].x != 5 {
+			t.Errorf("N = %d: крайние узлы %g и %g, ожидалось 1 и 5", n, points[0].x, points[n].x)
+		}
+		if err := validatePoints(points); err != nil {
+			t.Errorf("N = %d: %v", n, err)
+		}
+	}
+}
+
+func TestValidatePoints(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		points []point
+		valid  bool
+	}{
+		{"пустой набор", nil, true},
+		{"один узел", []point{{1, 2}}, true},
+		{"возрастающие", []point{{0, 1}, {0.5, 2}, {2, 0}}, true},
+		{"повторяющийся узел", []point{{0, 1}, {1, 2}, {1, 3}, {2, 0}}, false},
+		{"неупорядоченные", []point{{0, 1}, {2, 2}, {1, 3}}, false},
+		{"NaN", []point{{0, 1}, {math.NaN(), 2}}, false},
+	} {
+		if err := validatePoints(tc.points); (err == nil) != tc.valid {
+			t.Errorf("%s: validatePoints = %v", tc.name, err)
+		}
+	}
+}
+
+func TestConstructorsRejectDuplicateNodes(t *testing.T) {
+	data := &interpolationData{points: []point{{0, 1}, {1, 2}, {1, 2}, {2, 0}}, a: 0, b: 2, n: 3}
+	if _, err := newCubicSpline(data); err == nil {
+		t.Error("newCubicSpline: ожидалась ошибка для повторяющегося узла")
+	}
+	if _, err := newLagrangeInterpolator("Лагранж", data); err == nil {
+		t.Error("newLagrangeInterpolator: ожидалась ошибка для повторяющегося узла")
+	}
+	if _, err := sampleAt([]float64{0, 1, 1, 2}, testFunction); err == nil {
+		t.Error("sampleAt: ожидалась ошибка для повторяющегося узла")
+	}
+}
+
+func TestRungeUniformVersusChebyshev(t *testing.T) {
+	const n = 20
+	uniform, err := createGrid(-1, 1, n, rungeFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chebyshev, err := createChebyshevGrid(-1, 1, n, rungeFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uniformErr := maxError(rungeFunction, func(x float64) float64 { return lagrangeInterpolation(uniform, x) }, -1, 1, 1000)
+	chebyshevErr := maxError(rungeFunction, func(x float64) float64 { return lagrangeInterpolation(chebyshev, x) }, -1, 1, 1000)
+	// Около 60 на равномерных узлах и около 0.02 на узлах Чебышева
+	if !(uniformErr > 1 && chebyshevErr < 0.1 && uniformErr > 100*chebyshevErr) {
+		t.Errorf("N = %d: ошибка на равномерных узлах %.3e, на узлах Чебышева %.3e", n, uniformErr, chebyshevErr)
+	}
+}
+
+func TestSampleAt(t *testing.T) {
+	data, err := sampleAt([]float64{-1, 0.1, 2}, moduleFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []point{{-1, 1}, {0.1, 0.1}, {2, 2}}
+	if !slices.Equal(data.points, want) {
+		t.Errorf("узлы %v, ожидалось %v", data.points, want)
+	}
+	if data.a != -1 || data.b != 2 || data.n != 2 {
+		t.Errorf("a = %g, b = %g, n = %d, ожидалось -1, 2, 2", data.a, data.b, data.n)
+	}
+
+	for _, xs := range [][]float64{nil, {0, 2, 1}, {0, 0}} {
+		if _, err := sampleAt(xs, moduleFunction); err == nil {
+			t.Errorf("sampleAt(%v): ожидалась ошибка", xs)
+		}
+	}
+	if _, err := sampleAt([]float64{-1, 1}, math.Log); err == nil {
+		t.Error("ожидалась ошибка для неконечного значения функции")
+	}
+}
+
+func TestCompareInterpolationsResult(t *testing.T) {
+	methods := testInterpolators(t, 10)
+	result := compareInterpolations(methods, 1, 5, testFunction, defaultTableFormat())
+
+	if len(result.names) != len(methods) || len(result.errors) != len(methods) {
+		t.Fatalf("%d названий и %d сводок ошибок для %d методов", len(result.names), len(result.errors), len(methods))
+	}
+	if len(result.rows) != 20 {
+		t.Errorf("%d строк таблицы, ожидалось 20", len(result.rows))
+	}
+	for k, e := range result.errors {
+		if e.name != methods[k].Name() {
+			t.Errorf("сводка %d: метод %q, ожидался %q", k, e.name, methods[k].Name())
+		}
+		if math.IsNaN(e.maxErr) || math.IsInf(e.maxErr, 0) || e.maxErr < 0 {
+			t.Errorf("%s: максимальная ошибка %g", e.name, e.maxErr)
+		}
+	}
+	for _, row := range result.rows {
+		if len(row.values) != len(methods) {
+			t.Errorf("x = %g: %d значений для %d методов", row.x, len(row.values), len(methods))
+		}
+	}
+}
+
+func TestRefineGrid(t *testing.T) {
+	data, err := createGrid(1, 5, 5, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	refined, err := refineGrid(data, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if refined.n != 10 || len(refined.points) != 11 {
+		t.Fatalf("n = %d, узлов %d, ожидалось 10 и 11", refined.n, len(refined.points))
+	}
+	if refined.a != data.a || refined.b != data.b {
+		t.Errorf("отрезок [%g, %g], ожидался [%g, %g]", refined.a, refined.b, data.a, data.b)
+	}
+	if refined.points[0] != data.points[0] || refined.points[10] != data.points[5] {
+		t.Errorf("концы %v и %v, ожидались %v и %v", refined.points[0], refined.points[10], data.points[0], data.points[5])
+	}
+	for i, p := range refined.points {
+		want := data.points[i/2].x
+		if i%2 == 1 {
+			want = (data.points[i/2].x + data.points[i/2+1].x) / 2
+		}
+		if math.Abs(p.x-want) > 1e-12 || p.y != testFunction(p.x) {
+			t.Errorf("узел %d: %v, ожидалось x = %g, y = f(x)", i, p, want)
+		}
+	}
+}
+
+// captureStdout возвращает все, что f выводит в стандартный поток вывода
+func captureStdout(t *testing.T, f func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	out := make(chan string)
+	go func() {
+		content, _ := io.ReadAll(r)
+		out <- string(content)
+	}()
+	f()
+	w.Close()
+	return <-out
+}
+
+// fractionDigits возвращает количество цифр после запятой в числе s (в том числе в экспоненциальной форме)
+func fractionDigits(s string) int {
+	_, frac, ok := strings.Cut(s, ".")
+	if !ok {
+		return 0
+	}
+	mantissa, _, _ := strings.Cut(frac, "e")
+	return len(mantissa)
+}
+
+func TestTableFormatPrecision(t *testing.T) {
+	data, err := createGrid(1, 5, 4, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Числовые поля строк таблицы исходных данных
+	fields := func(format TableFormat) []string {
+		out := captureStdout(t, func() { printTable(data, "равномерная сетка", format) })
+		var values []string
+		for _, line := range strings.Split(out, "\n") {
+			if f := strings.Fields(line); len(f) == 2 {
+				if _, err := strconv.ParseFloat(f[1], 64); err == nil {
+					values = append(values, f[1])
+				}
+			}
+		}
+		if len(values) != len(data.points) {
+			t.Fatalf("%d строк со значениями, ожидалось %d:\n%s", len(values), len(data.points), out)
+		}
+		return values
+	}
+
+	short := fields(TableFormat{Precision: 2})
+	long := fields(defaultTableFormat())
+	for i := range short {
+		if fractionDigits(short[i]) != 2 || fractionDigits(long[i]) != 6 || len(short[i]) != len(long[i])-4 {
+			t.Errorf("узел %d: %q при точности 2 и %q по умолчанию", i, short[i], long[i])
+		}
+	}
+
+	methods := testInterpolators(t, 10)
+	out := captureStdout(t, func() {
+		compareInterpolations(methods, 1, 5, testFunction, TableFormat{Precision: 2, Scientific: true})
+	})
+	rows := 0
+	for _, line := range strings.Split(out, "\n") {
+		f := strings.Fields(line)
+		if len(f) != 2+3*len(methods) || f[0] == "x" {
+			continue
+		}
+		rows++
+		for _, s := range f[1:] {
+			if _, err := strconv.ParseFloat(s, 64); err == nil && (fractionDigits(s) != 2 || !strings.Contains(s, "e")) {
+				t.Errorf("поле %q в строке %q: ожидалось 2 знака в экспоненциальной форме", s, line)
+			}
+		}
+	}
+	if rows != 20 {
+		t.Errorf("%d строк таблицы сравнения, ожидалось 20", rows)
+	}
+}
+
+func TestCreateLogGrid(t *testing.T) {
+	data, err := createLogGrid(1, 5, 8, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data.points) != 9 || data.points[0].x != 1 || data.points[8].x != 5 {
+		t.Fatalf("узлы %v, ожидалось 9 узлов от 1 до 5", data.points)
+	}
+
+	// Шаги растут в геометрической прогрессии: x(i+1)/x(i) = (b/a)^(1/n)
+	ratio := math.Pow(5, 1.0/8)
+	for i := 1; i < len(data.points); i++ {
+		if r := data.points[i].x / data.points[i-1].x; math.Abs(r-ratio) > 1e-12 {
+			t.Errorf("x[%d]/x[%d] = %.15g, ожидалось %.15g", i, i-1, r, ratio)
+		}
+		if p := data.points[i]; p.y != testFunction(p.x) {
+			t.Errorf("узел %d: y = %g, ожидалось f(x) = %g", i, p.y, testFunction(p.x))
+		}
+	}
+
+	for _, c := range []struct{ a, b float64 }{{0, 5}, {-1, 5}, {5, 1}} {
+		if _, err := createLogGrid(c.a, c.b, 8, testFunction); err == nil {
+			t.Errorf("createLogGrid(%g, %g): ожидалась ошибка", c.a, c.b)
+		}
+	}
+	if _, err := createLogGrid(1, 5, 0, testFunction); err == nil {
+		t.Error("ожидалась ошибка для нулевого количества отрезков")
+	}
+}
+
+func TestAdaptiveGridConcentratesNearKink(t *testing.T) {
+	const maxNodes = 21
+	data := adaptiveGrid(-1, 2, moduleFunction, maxNodes, 1e-6)
+	if len(data.points) != maxNodes || data.n != maxNodes-1 {
+		t.Fatalf("%d узлов, n = %d, ожидалось %d узлов", len(data.points), data.n, maxNodes)
+	}
+	if err := validatePoints(data.points); err != nil {
+		t.Fatal(err)
+	}
+	if first, last := data.points[0].x, data.points[maxNodes-1].x; first != -1 || last != 2 {
+		t.Errorf("концы %g и %g, ожидались -1 и 2", first, last)
+	}
+
+	uniform, err := createGrid(-1, 2, maxNodes-1, moduleFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nearKink := func(points []point) int {
+		count := 0
+		for _, p := range points {
+			if math.Abs(p.x) <= 0.1 {
+				count++
+			}
+		}
+		return count
+	}
+	if adaptive, uniformCount := nearKink(data.points), nearKink(uniform.points); adaptive <= 2*uniformCount {
+		t.Errorf("в окрестности излома %d адаптивных узлов и %d равномерных", adaptive, uniformCount)
+	}
+
+	// Без излома функция линейна, и достаточно концов отрезка
+	if sparse := adaptiveGrid(0.5, 2, moduleFunction, maxNodes, 1e-6); len(sparse.points) != 2 {
+		t.Errorf("для линейной функции %d узлов, ожидалось 2", len(sparse.points))
+	}
+}
+
+func TestAddNoiseDeterministic(t *testing.T) {
+	data, err := createGrid(1, 5, 10, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := slices.Clone(data.points)
+
+	const amplitude = 0.1
+	first, second, other := addNoise(data, amplitude, 42), addNoise(data, amplitude, 42), addNoise(data, amplitude, 43)
+	if !slices.Equal(first.points, second.points) {
+		t.Errorf("одно зерно дало разный шум:\n%v\n%v", first.points, second.points)
+	}
+	if slices.Equal(first.points, other.points) {
+		t.Error("разные зерна дали одинаковый шум")
+	}
+
+	for i, p := range first.points {
+		if p.x != data.points[i].x || math.Abs(p.y-data.points[i].y) > amplitude {
+			t.Errorf("узел %d: %v, исходный %v, амплитуда %g", i, p, data.points[i], amplitude)
+		}
+	}
+	if first.a != data.a || first.b != data.b || first.n != data.n {
+		t.Errorf("параметры сетки (%g, %g, %d), ожидалось (%g, %g, %d)", first.a, first.b, first.n, data.a, data.b, data.n)
+	}
+	if !slices.Equal(data.points, original) {
+		t.Error("addNoise изменила исходную сетку")
+	}
+}
+
+func TestSolveLinearSystemSingular(t *testing.T) {
+	// Третья строка - сумма первых двух: система вырождена, а не имеет "какое-то" решение
+	singular := matrixFrom([][]float64{
+		{1, 2, 3},
+		{2, -1, 4},
+		{3, 1, 7},
+	})
+	x, err := solveLinearSystem(singular, []float64{1, 2, 3})
+	if !errors.Is(err, errSingularMatrix) {
+		t.Errorf("для вырожденной матрицы получено %v, %v, ожидалось errSingularMatrix", x, err)
+	}
+
+	// Нулевой диагональный элемент при невырожденной матрице устраняется выбором ведущего элемента
+	a := matrixFrom([][]float64{
+		{0, 1, 2},
+		{1, 0, 3},
+		{4, -3, 8},
+	})
+	want := []float64{1, -2, 3}
+	x, err = solveLinearSystem(a, a.mulVec(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !vectorsClose(x, want, 1e-12) {
+		t.Errorf("решение %v, ожидалось %v", x, want)
+	}
+}
+
+func TestCreateGridRejectsNonFiniteValues(t *testing.T) {
+	// На сетке [-1, 1] с 4 отрезками узел x = 0 попадает в полюс
+	pole := func(x float64) float64 { return 1 / x }
+	_, err := createGrid(-1, 1, 4, pole)
+	if err == nil {
+		t.Fatal("ожидалась ошибка для бесконечного значения функции")
+	}
+	if msg := err.Error(); !strings.Contains(msg, "x = 0") || !strings.Contains(msg, "+Inf") {
+		t.Errorf("в сообщении %q нет узла и значения", msg)
+	}
+
+	// log10 отрицательного аргумента - NaN, в том числе на других типах сеток
+	if _, err := createChebyshevGrid(-3, 5, 6, testFunction); err == nil || !strings.Contains(err.Error(), "NaN") {
+		t.Errorf("сетка Чебышева с NaN в узле: %v", err)
+	}
+	if _, err := createGrid(1, 5, 4, testFunction); err != nil {
+		t.Errorf("конечные значения: %v", err)
+	}
+}
+
+func TestCompareInterpolationsTiming(t *testing.T) {
+	methods := testInterpolators(t, 10)
+	result := compareInterpolations(methods, 1, 5, testFunction, defaultTableFormat())
+
+	timedSplines := 0
+	for k, m := range methods {
+		e := result.errors[k]
+		if e.evalTime <= 0 || e.buildTime < 0 {
+			t.Errorf("%s: построение %v, вычисление %v", e.name, e.buildTime, e.evalTime)
+		}
+
+		// Время построения берется у интерполятора; у методов без построения оно нулевое
+		timed, ok := m.(timedInterpolator)
+		switch {
+		case ok:
+			timedSplines++
+			if e.buildTime != timed.BuildTime() || e.buildTime <= 0 {
+				t.Errorf("%s: время построения %v, у интерполятора %v", e.name, e.buildTime, timed.BuildTime())
+			}
+		case e.buildTime != 0:
+			t.Errorf("%s: время построения %v у метода без построения", e.name, e.buildTime)
+		}
+	}
+	if timedSplines != 2 {
+		t.Errorf("время построения записано у %d методов, ожидалось у двух сплайнов", timedSplines)
+	}
+}
+
+func TestLinspace(t *testing.T) {
+	if got, want := linspace(0, 1, 5), []float64{0, 0.25, 0.5, 0.75, 1}; !slices.Equal(got, want) {
+		t.Errorf("linspace(0, 1, 5) = %v, ожидалось %v", got, want)
+	}
+
+	// Правый конец точен даже там, где a + (n-1)*h дает ошибку округления
+	xs := linspace(1, 5, 100)
+	if len(xs) != 100 || xs[0] != 1 || xs[99] != 5 {
+		t.Errorf("linspace(1, 5, 100): %d точек от %g до %g", len(xs), xs[0], xs[len(xs)-1])
+	}
+	for i := 1; i < len(xs); i++ {
+		if step := xs[i] - xs[i-1]; math.Abs(step-4.0/99) > 1e-14 {
+			t.Errorf("шаг между точками %d и %d равен %g, ожидалось %g", i-1, i, step, 4.0/99)
+		}
+	}
+
+	if got := linspace(2, 3, 1); !slices.Equal(got, []float64{2}) {
+		t.Errorf("linspace(2, 3, 1) = %v, ожидалось [2]", got)
+	}
+	if got := linspace(2, 3, 0); got != nil {
+		t.Errorf("linspace(2, 3, 0) = %v, ожидалось nil", got)
+	}
+}
@@ -0,0 +1,461 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// mulVec умножает матрицу на вектор v
+func (m *matrix) mulVec(v []float64) []float64 {
+	if len(v) != m.cols {
+		panic(fmt.Sprintf("mulVec: матрица %dx%d несовместима с вектором длины %d", m.rows, m.cols, len(v)))
+	}
+
+	result := make([]float64, m.rows)
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < m.cols; j++ {
+			result[i] += m.get(i, j) * v[j]
+		}
+	}
+	return result
+}
+
+// mul умножает матрицу на матрицу other
+func (m *matrix) mul(other *matrix) *matrix {
+	if m.cols != other.rows {
+		panic(fmt.Sprintf("mul: матрицы %dx%d и %dx%d несовместимы", m.rows, m.cols, other.rows, other.cols))
+	}
+
+	result := newMatrix(m.rows, other.cols)
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < other.cols; j++ {
+			sum := 0.0
+			for k := 0; k < m.cols; k++ {
+				sum += m.get(i, k) * other.get(k, j)
+			}
+			result.set(i, j, sum)
+		}
+	}
+	return result
+}
+
+// transpose возвращает новую транспонированную матрицу размера cols x rows
+func (m *matrix) transpose() *matrix {
+	result := newMatrix(m.cols, m.rows)
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < m.cols; j++ {
+			result.set(j, i, m.get(i, j))
+		}
+	}
+	return result
+}
+
+// isSymmetric проверяет, что матрица квадратная и |a(i, j) - a(j, i)| <= tol для всех i, j
+func (m *matrix) isSymmetric(tol float64) bool {
+	if m.rows != m.cols {
+		return false
+	}
+	for i := 0; i < m.rows; i++ {
+		for j := i + 1; j < m.cols; j++ {
+			if !(math.Abs(m.get(i, j)-m.get(j, i)) <= tol) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// checkRow паникует, если i - не номер строки матрицы
+func (m *matrix) checkRow(op string, i int) {
+	if i < 0 || i >= m.rows {
+		panic(fmt.Sprintf("%s: строка %d вне диапазона [0, %d) матрицы %dx%d", op, i, m.rows, m.rows, m.cols))
+	}
+}
+
+// scaleRow умножает строку i на s
+func (m *matrix) scaleRow(i int, s float64) {
+	m.checkRow("scaleRow", i)
+	for j := range m.data[i] {
+		m.data[i][j] *= s
+	}
+}
+
+// swapRows меняет местами строки i и j
+func (m *matrix) swapRows(i, j int) {
+	m.checkRow("swapRows", i)
+	m.checkRow("swapRows", j)
+	m.data[i], m.data[j] = m.data[j], m.data[i]
+}
+
+// addScaledRow прибавляет к строке dest строку src, умноженную на s
+func (m *matrix) addScaledRow(dest, src int, s float64) {
+	m.checkRow("addScaledRow", dest)
+	m.checkRow("addScaledRow", src)
+	for j := range m.data[dest] {
+		m.data[dest][j] += s * m.data[src][j]
+	}
+}
+
+// errSingularMatrix сообщает, что матрица вырождена (или численно близка к вырожденной)
+var errSingularMatrix = errors.New("матрица вырождена")
+
+// luDecompose вычисляет LU-разложение PA = LU с частичным выбором ведущего элемента.
+// В матрице lu под диагональю хранится L (с единицами на диагонали), на и над диагональю - U;
+// perm[i] - номер строки исходной матрицы, оказавшейся на месте i
+func luDecompose(a *matrix) (lu *matrix, perm []int, err error) {
+	if a.rows != a.cols {
+		return nil, nil, fmt.Errorf("LU-разложение требует квадратную матрицу, получена %dx%d", a.rows, a.cols)
+	}
+	n := a.rows
+
+	lu = newMatrix(n, n)
+	for i := 0; i < n; i++ {
+		copy(lu.data[i], a.data[i])
+	}
+	perm = make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+
+	for k := 0; k < n; k++ {
+		// Выбираем ведущий элемент с максимальным модулем в столбце k
+		pivot := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(lu.get(i, k)) > math.Abs(lu.get(pivot, k)) {
+				pivot = i
+			}
+		}
+		if math.Abs(lu.get(pivot, k)) < 1e-12 {
+			return nil, nil, fmt.Errorf("%w: нулевой ведущий элемент в столбце %d", errSingularMatrix, k)
+		}
+		if pivot != k {
+			lu.swapRows(k, pivot)
+			perm[k], perm[pivot] = perm[pivot], perm[k]
+		}
+
+		// Исключаем элементы под диагональю, сохраняя множители на их месте
+		for i := k + 1; i < n; i++ {
+			factor := lu.get(i, k) / lu.get(k, k)
+			lu.set(i, k, factor)
+			for j := k + 1; j < n; j++ {
+				lu.set(i, j, lu.get(i, j)-factor*lu.get(k, j))
+			}
+		}
+	}
+
+	return lu, perm, nil
+}
+
+// luSolve решает систему Ax = b по готовому LU-разложению за O(n^2)
+func luSolve(lu *matrix, perm []int, b []float64) []float64 {
+	n := lu.rows
+
+	// Прямая подстановка: Ly = Pb
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		y[i] = b[perm[i]]
+		for j := 0; j < i; j++ {
+			y[i] -= lu.get(i, j) * y[j]
+		}
+	}
+
+	// Обратная подстановка: Ux = y
+	x := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		x[i] = y[i]
+		for j := i + 1; j < n; j++ {
+			x[i] -= lu.get(i, j) * x[j]
+		}
+		x[i] /= lu.get(i, i)
+	}
+
+	return x
+}
+
+// inverse вычисляет обратную матрицу методом Гаусса-Жордана с частичным выбором ведущего
+// элемента: расширенная матрица [A | I] приводится к виду [I | A^-1]. Для вырожденной
+// матрицы возвращает ошибку errSingularMatrix
+func (m *matrix) inverse() (*matrix, error) {
+	if m.rows != m.cols {
+		return nil, fmt.Errorf("обратная матрица существует только для квадратной матрицы, получена %dx%d", m.rows, m.cols)
+	}
+	n := m.rows
+
+	augmented := newMatrix(n, 2*n)
+	for i := 0; i < n; i++ {
+		copy(augmented.data[i], m.data[i])
+		augmented.set(i, n+i, 1)
+	}
+
+	for k := 0; k < n; k++ {
+		// Выбираем ведущий элемент с максимальным модулем в столбце k
+		pivot := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(augmented.get(i, k)) > math.Abs(augmented.get(pivot, k)) {
+				pivot = i
+			}
+		}
+		if math.Abs(augmented.get(pivot, k)) < 1e-12 {
+			return nil, fmt.Errorf("%w: нулевой ведущий элемент в столбце %d", errSingularMatrix, k)
+		}
+		if pivot != k {
+			augmented.swapRows(k, pivot)
+		}
+
+		// Нормируем ведущую строку и исключаем столбец k из всех остальных строк
+		augmented.scaleRow(k, 1/augmented.get(k, k))
+		for i := 0; i < n; i++ {
+			if i != k {
+				augmented.addScaledRow(i, k, -augmented.get(i, k))
+			}
+		}
+	}
+
+	inv := newMatrix(n, n)
+	for i := 0; i < n; i++ {
+		copy(inv.data[i], augmented.data[i][n:])
+	}
+	return inv, nil
+}
+
+// determinant вычисляет определитель матрицы как произведение диагонали U из LU-разложения
+// с учетом знака перестановки строк. Для вырожденной матрицы возвращает 0
+func (m *matrix) determinant() float64 {
+	lu, perm, err := luDecompose(m)
+	if errors.Is(err, errSingularMatrix) {
+		return 0
+	}
+	if err != nil {
+		return math.NaN()
+	}
+
+	det := permutationSign(perm)
+	for i := 0; i < lu.rows; i++ {
+		det *= lu.get(i, i)
+	}
+	return det
+}
+
+// permutationSign возвращает знак перестановки: +1 для четной, -1 для нечетной
+func permutationSign(perm []int) float64 {
+	sign := 1.0
+	visited := make([]bool, len(perm))
+	for i := range perm {
+		if visited[i] {
+			continue
+		}
+		// Цикл длины k дает k-1 транспозиций
+		length := 0
+		for j := i; !visited[j]; j = perm[j] {
+			visited[j] = true
+			length++
+		}
+		if length%2 == 0 {
+			sign = -sign
+		}
+	}
+	return sign
+}
+
+// norm1 вычисляет 1-норму матрицы (максимальную сумму модулей по столбцам)
+func (m *matrix) norm1() float64 {
+	result := 0.0
+	for j := 0; j < m.cols; j++ {
+		sum := 0.0
+		for i := 0; i < m.rows; i++ {
+			sum += math.Abs(m.get(i, j))
+		}
+		result = math.Max(result, sum)
+	}
+	return result
+}
+
+// conditionNumberEstimate оценивает число обусловленности ||A||1 * ||A^-1||1.
+// Столбцы обратной матрицы находятся через одно LU-разложение; для вырожденной
+// матрицы возвращается +Inf
+func (m *matrix) conditionNumberEstimate() float64 {
+	lu, perm, err := luDecompose(m)
+	if err != nil {
+		return math.Inf(1)
+	}
+
+	n := m.rows
+	inverseNorm := 0.0
+	e := make([]float64, n)
+	for j := 0; j < n; j++ {
+		e[j] = 1
+		column := luSolve(lu, perm, e)
+		e[j] = 0
+
+		sum := 0.0
+		for _, v := range column {
+			sum += math.Abs(v)
+		}
+		inverseNorm = math.Max(inverseNorm, sum)
+	}
+
+	return m.norm1() * inverseNorm
+}
+
+// gaussSeidel решает систему Ax = b методом Зейделя, начиная с нулевого приближения.
+// Итерации продолжаются, пока евклидова норма невязки не станет меньше tol.
+// Сходимость гарантирована для матриц с диагональным преобладанием
+func gaussSeidel(a *matrix, b []float64, tol float64, maxIter int) ([]float64, int, error) {
+	n := a.rows
+	for i := 0; i < n; i++ {
+		if a.get(i, i) == 0 {
+			return nil, 0, fmt.Errorf("нулевой диагональный элемент в строке %d", i)
+		}
+	}
+
+	x := make([]float64, n)
+	for iter := 1; iter <= maxIter; iter++ {
+		for i := 0; i < n; i++ {
+			sum := b[i]
+			for j := 0; j < n; j++ {
+				if j != i {
+					sum -= a.get(i, j) * x[j]
+				}
+			}
+			x[i] = sum / a.get(i, i)
+		}
+
+		// Проверяем норму невязки ||Ax - b||
+		if residualNorm(a, x, b) < tol {
+			return x, iter, nil
+		}
+	}
+
+	return x, maxIter, fmt.Errorf("метод Зейделя не сошелся за %d итераций", maxIter)
+}
+
+// residualNorm возвращает евклидову норму невязки ||Ax - b|| приближенного решения x
+func residualNorm(a *matrix, x, b []float64) float64 {
+	ax := a.mulVec(x)
+	sum := 0.0
+	for i := range ax {
+		sum += (ax[i] - b[i]) * (ax[i] - b[i])
+	}
+	return math.Sqrt(sum)
+}
+
+// qrDecompose вычисляет QR-разложение матрицы a (rows >= cols) отражениями Хаусхолдера:
+// A = QR, где Q - ортогональная матрица rows x rows, R - верхнетреугольная rows x cols
+func qrDecompose(a *matrix) (q, r *matrix) {
+	m, n := a.rows, a.cols
+
+	r = newMatrix(m, n)
+	for i := 0; i < m; i++ {
+		copy(r.data[i], a.data[i])
+	}
+	q = newMatrix(m, m)
+	for i := 0; i < m; i++ {
+		q.set(i, i, 1)
+	}
+
+	v := make([]float64, m)
+	for k := 0; k < n && k < m-1; k++ {
+		// Вектор отражения v = x + sign(x0)*||x||*e0 для столбца k ниже диагонали
+		norm := 0.0
+		for i := k; i < m; i++ {
+			norm += r.get(i, k) * r.get(i, k)
+		}
+		norm = math.Sqrt(norm)
+		if norm == 0 {
+			continue
+		}
+
+		alpha := -norm
+		if r.get(k, k) < 0 {
+			alpha = norm
+		}
+		vNorm := 0.0
+		for i := k; i < m; i++ {
+			v[i] = r.get(i, k)
+			if i == k {
+				v[i] -= alpha
+			}
+			vNorm += v[i] * v[i]
+		}
+		if vNorm == 0 {
+			continue
+		}
+
+		// R = (I - 2vv^T/v^Tv) R
+		for j := 0; j < n; j++ {
+			dot := 0.0
+			for i := k; i < m; i++ {
+				dot += v[i] * r.get(i, j)
+			}
+			factor := 2 * dot / vNorm
+			for i := k; i < m; i++ {
+				r.set(i, j, r.get(i, j)-factor*v[i])
+			}
+		}
+
+		// Q = Q (I - 2vv^T/v^Tv)
+		for i := 0; i < m; i++ {
+			dot := 0.0
+			for j := k; j < m; j++ {
+				dot += q.get(i, j) * v[j]
+			}
+			factor := 2 * dot / vNorm
+			for j := k; j < m; j++ {
+				q.set(i, j, q.get(i, j)-factor*v[j])
+			}
+		}
+	}
+
+	return q, r
+}
+
+// qrRankEps - порог относительной малости диагонального элемента R, при котором
+// столбцы A считаются линейно зависимыми
+const qrRankEps = 1e-12
+
+// checkQRRank проверяет, что диагональ верхнетреугольной R не содержит элементов,
+// малых относительно максимального по модулю, т. е. что столбцы A линейно независимы
+func checkQRRank(r *matrix) error {
+	maxDiag := 0.0
+	for i := 0; i < r.cols; i++ {
+		maxDiag = math.Max(maxDiag, math.Abs(r.get(i, i)))
+	}
+	for i := 0; i < r.cols; i++ {
+		if math.Abs(r.get(i, i)) <= qrRankEps*maxDiag {
+			return fmt.Errorf("%w: R[%d][%d] = %g, столбцы матрицы линейно зависимы", errSingularMatrix, i, i, r.get(i, i))
+		}
+	}
+	return nil
+}
+
+// qrSolveLeastSquares решает переопределенную систему Ax ≈ b по методу наименьших квадратов
+// через QR-разложение: Rx = Q^T b. В отличие от нормальных уравнений число обусловленности
+// не возводится в квадрат. Для матрицы неполного столбцового ранга возвращает ошибку errSingularMatrix
+func qrSolveLeastSquares(a *matrix, b []float64) ([]float64, error) {
+	q, r := qrDecompose(a)
+	m, n := a.rows, a.cols
+	if err := checkQRRank(r); err != nil {
+		return nil, err
+	}
+
+	// Q^T b, нужны только первые n компонент
+	qtb := make([]float64, n)
+	for j := 0; j < n; j++ {
+		for i := 0; i < m; i++ {
+			qtb[j] += q.get(i, j) * b[i]
+		}
+	}
+
+	// Обратная подстановка для верхнетреугольной R
+	x := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		x[i] = qtb[i]
+		for j := i + 1; j < n; j++ {
+			x[i] -= r.get(i, j) * x[j]
+		}
+		x[i] /= r.get(i, i)
+	}
+
+	return x, nil
+}
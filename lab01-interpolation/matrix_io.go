@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// saveMatrix записывает матрицу в текстовый файл: первая строка - "rows cols",
+// далее по строке файла на строку матрицы, элементы разделены пробелами
+func saveMatrix(m *matrix, filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	fmt.Fprintf(writer, "%d %d\n", m.rows, m.cols)
+	for i := 0; i < m.rows; i++ {
+		fields := make([]string, m.cols)
+		for j := 0; j < m.cols; j++ {
+			fields[j] = formatCSVFloat(m.get(i, j))
+		}
+		fmt.Fprintln(writer, strings.Join(fields, " "))
+	}
+
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// loadMatrix читает матрицу из текстового файла в формате saveMatrix. Пустые строки
+// пропускаются; строка с числом элементов, отличным от cols, считается ошибкой
+func loadMatrix(filename string) (*matrix, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var m *matrix
+	row := 0
+	lineNum := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lineNum++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		// Первая непустая строка - заголовок с размерами
+		if m == nil {
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("%s:%d: заголовок должен содержать число строк и столбцов", filename, lineNum)
+			}
+			rows, errRows := strconv.Atoi(fields[0])
+			cols, errCols := strconv.Atoi(fields[1])
+			if errRows != nil || errCols != nil || rows < 0 || cols < 0 {
+				return nil, fmt.Errorf("%s:%d: некорректные размеры матрицы %q", filename, lineNum, scanner.Text())
+			}
+			m = newMatrix(rows, cols)
+			continue
+		}
+
+		if row >= m.rows {
+			return nil, fmt.Errorf("%s:%d: лишняя строка, ожидалось %d строк", filename, lineNum, m.rows)
+		}
+		if len(fields) != m.cols {
+			return nil, fmt.Errorf("%s:%d: ожидалось %d элементов в строке, получено %d", filename, lineNum, m.cols, len(fields))
+		}
+		for j, field := range fields {
+			v, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: некорректное число %q", filename, lineNum, field)
+			}
+			m.set(row, j, v)
+		}
+		row++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("чтение %s: %w", filename, err)
+	}
+
+	if m == nil {
+		return nil, fmt.Errorf("%s: нет заголовка с размерами матрицы", filename)
+	}
+	if row != m.rows {
+		return nil, fmt.Errorf("%s: ожидалось %d строк матрицы, получено %d", filename, m.rows, row)
+	}
+	return m, nil
+}
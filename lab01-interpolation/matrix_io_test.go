@@ -0,0 +1,50 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSaveLoadMatrixRoundTrip(t *testing.T) {
+	m := matrixFrom([][]float64{
+		{1, -2.5, 0, 1.0 / 3},
+		{1e-300, 4, -7, 0.1},
+		{2, 1e20, -0.5, 6},
+	})
+	filename := filepath.Join(t.TempDir(), "matrix.txt")
+	if err := saveMatrix(m, filename); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := loadMatrix(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.rows != 3 || loaded.cols != 4 || !matrixEquals(loaded, m.data) {
+		t.Errorf("прочитана матрица %dx%d %v, ожидалась 3x4 %v", loaded.rows, loaded.cols, loaded.data, m.data)
+	}
+}
+
+func TestLoadMatrixErrors(t *testing.T) {
+	dir := t.TempDir()
+	for _, tc := range []struct {
+		name, content, want string
+	}{
+		{"рваная строка", "2 3\n1 2 3\n4 5\n", "ожидалось 3 элементов"},
+		{"лишняя строка", "1 2\n1 2\n3 4\n", "лишняя строка"},
+		{"не хватает строк", "3 1\n1\n2\n", "ожидалось 3 строк"},
+		{"нет заголовка", "\n\n", "нет заголовка"},
+		{"плохой заголовок", "2 x\n1 2\n", "некорректные размеры"},
+		{"не число", "1 2\n1 abc\n", "некорректное число"},
+	} {
+		filename := filepath.Join(dir, "matrix.txt")
+		if err := os.WriteFile(filename, []byte(tc.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loadMatrix(filename); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: ошибка %v, ожидалась содержащая %q", tc.name, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,437 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+// matrixFrom создает матрицу по строкам
+func matrixFrom(rows [][]float64) *matrix {
+	m := newMatrix(len(rows), len(rows[0]))
+	for i, row := range rows {
+		for j, v := range row {
+			m.set(i, j, v)
+		}
+	}
+	return m
+}
+
+// expectPanic проверяет, что f паникует
+func expectPanic(t *testing.T, name string, f func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("%s: ожидалась паника", name)
+		}
+	}()
+	f()
+}
+
+// vectorsClose сообщает, совпадают ли векторы поэлементно с точностью tol
+func vectorsClose(x, y []float64, tol float64) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if math.Abs(x[i]-y[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMatrixMulVec(t *testing.T) {
+	a := matrixFrom([][]float64{
+		{1, 2, 3},
+		{0, -1, 4},
+		{2, 0, 1},
+	})
+	if got, want := a.mulVec([]float64{1, -1, 2}), []float64{5, 9, 4}; !vectorsClose(got, want, 0) {
+		t.Errorf("A*v = %v, ожидалось %v", got, want)
+	}
+
+	// Тот же результат через умножение на матрицу 3x1
+	column := matrixFrom([][]float64{{1}, {-1}, {2}})
+	product := a.mul(column)
+	if product.rows != 3 || product.cols != 1 {
+		t.Fatalf("размер произведения %dx%d, ожидалось 3x1", product.rows, product.cols)
+	}
+	for i, want := range []float64{5, 9, 4} {
+		if product.get(i, 0) != want {
+			t.Errorf("(A*B)[%d] = %g, ожидалось %g", i, product.get(i, 0), want)
+		}
+	}
+}
+
+func TestMatrixMul(t *testing.T) {
+	a := matrixFrom([][]float64{{1, 2}, {3, 4}, {5, 6}})
+	b := matrixFrom([][]float64{{1, 0, -1}, {2, 1, 0}})
+	want := [][]float64{{5, 2, -1}, {11, 4, -3}, {17, 6, -5}}
+
+	product := a.mul(b)
+	if product.rows != 3 || product.cols != 3 {
+		t.Fatalf("размер произведения %dx%d, ожидалось 3x3", product.rows, product.cols)
+	}
+	for i := range want {
+		for j := range want[i] {
+			if product.get(i, j) != want[i][j] {
+				t.Errorf("(A*B)[%d][%d] = %g, ожидалось %g", i, j, product.get(i, j), want[i][j])
+			}
+		}
+	}
+}
+
+func TestMatrixMulDimensionMismatch(t *testing.T) {
+	a := newMatrix(3, 3)
+	expectPanic(t, "mulVec", func() { a.mulVec([]float64{1, 2}) })
+	expectPanic(t, "mul", func() { a.mul(newMatrix(2, 3)) })
+}
+
+// testSystemMatrix - невырожденная матрица без диагонального преобладания, при разложении
+// которой строки переставляются
+func testSystemMatrix() *matrix {
+	return matrixFrom([][]float64{
+		{1, 2, -1, 0},
+		{4, 1, 0, 2},
+		{-2, 3, 5, 1},
+		{0, 1, 2, 7},
+	})
+}
+
+func TestLUSolveMultipleRightHandSides(t *testing.T) {
+	a := testSystemMatrix()
+	lu, perm, err := luDecompose(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, b := range [][]float64{
+		{1, 0, 0, 0},
+		{3, -1, 2, 5},
+		{-10, 0.5, 7, 1e3},
+	} {
+		x := luSolve(lu, perm, b)
+		want, err := solveLinearSystem(a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !vectorsClose(x, want, 1e-10) {
+			t.Errorf("b = %v: LU дает %v, метод Гаусса %v", b, x, want)
+		}
+		if r := residualNorm(a, x, b); r > 1e-10 {
+			t.Errorf("b = %v: невязка %.3e", b, r)
+		}
+	}
+}
+
+func TestLUDecomposeErrors(t *testing.T) {
+	if _, _, err := luDecompose(newMatrix(2, 3)); err == nil {
+		t.Error("ожидалась ошибка для неквадратной матрицы")
+	}
+	singular := matrixFrom([][]float64{{1, 2}, {2, 4}})
+	if _, _, err := luDecompose(singular); !errors.Is(err, errSingularMatrix) {
+		t.Errorf("для вырожденной матрицы получено %v, ожидалось errSingularMatrix", err)
+	}
+}
+
+func TestDeterminant(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		m    *matrix
+		det  float64
+	}{
+		{"2x2", matrixFrom([][]float64{{3, 8}, {4, 6}}), -14},
+		{"3x3", matrixFrom([][]float64{{6, 1, 1}, {4, -2, 5}, {2, 8, 7}}), -306},
+		{"перестановка", matrixFrom([][]float64{{0, 1, 0}, {0, 0, 1}, {1, 0, 0}}), 1},
+		{"вырожденная", matrixFrom([][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}), 0},
+	} {
+		if det := tc.m.determinant(); math.Abs(det-tc.det) > 1e-10 {
+			t.Errorf("%s: определитель %g, ожидалось %g", tc.name, det, tc.det)
+		}
+	}
+}
+
+func TestConditionNumberEstimate(t *testing.T) {
+	identity := matrixFrom([][]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}})
+	if c := identity.conditionNumberEstimate(); math.Abs(c-1) > 1e-12 {
+		t.Errorf("число обусловленности единичной матрицы %g, ожидалось 1", c)
+	}
+
+	// Матрица Гильберта 6x6: число обусловленности в 1-норме около 2.9e7
+	const n = 6
+	hilbert := newMatrix(n, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			hilbert.set(i, j, 1/float64(i+j+1))
+		}
+	}
+	if c := hilbert.conditionNumberEstimate(); !(c > 1e7) {
+		t.Errorf("число обусловленности матрицы Гильберта %g, ожидалось больше 1e7", c)
+	}
+
+	if c := matrixFrom([][]float64{{1, 2}, {2, 4}}).conditionNumberEstimate(); !math.IsInf(c, 1) {
+		t.Errorf("число обусловленности вырожденной матрицы %g, ожидалось +Inf", c)
+	}
+}
+
+func TestGaussSeidelSplineSystem(t *testing.T) {
+	data, err := createGrid(1, 5, 20, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sys := naturalSplineSystem(t, data)
+	a := denseMatrix(sys.lower, sys.diag, sys.upper)
+
+	const tol = 1e-10
+	x, iterations, err := gaussSeidel(a, sys.rhs, tol, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := solveLinearSystem(a, sys.rhs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !vectorsClose(x, want, tol) {
+		t.Errorf("метод Зейделя (%d итераций) дает %v, метод Гаусса %v", iterations, x, want)
+	}
+	// Система сплайна с диагональным преобладанием сходится быстро
+	if iterations > 50 {
+		t.Errorf("метод Зейделя сошелся только за %d итераций", iterations)
+	}
+}
+
+func TestGaussSeidelNotConverging(t *testing.T) {
+	// Без диагонального преобладания итерации расходятся
+	a := matrixFrom([][]float64{{1, 3}, {2, 1}})
+	if _, iterations, err := gaussSeidel(a, []float64{1, 1}, 1e-10, 30); err == nil || iterations != 30 {
+		t.Errorf("ожидалась ошибка сходимости после 30 итераций, получено %d итераций, %v", iterations, err)
+	}
+}
+
+func TestQRDecompose(t *testing.T) {
+	a := matrixFrom([][]float64{
+		{2, -1, 0},
+		{1, 3, 1},
+		{0, 1, 4},
+		{-1, 2, 1},
+		{3, 0, -2},
+	})
+	q, r := qrDecompose(a)
+
+	// Q ортогональна, R верхнетреугольная, QR = A
+	qtq := q.transpose().mul(q)
+	qr := q.mul(r)
+	for i := 0; i < a.rows; i++ {
+		for j := 0; j < a.rows; j++ {
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			if math.Abs(qtq.get(i, j)-want) > 1e-12 {
+				t.Errorf("(Q^T Q)[%d][%d] = %g, ожидалось %g", i, j, qtq.get(i, j), want)
+			}
+		}
+		for j := 0; j < a.cols; j++ {
+			if j < i && math.Abs(r.get(i, j)) > 1e-12 {
+				t.Errorf("R[%d][%d] = %g под диагональю", i, j, r.get(i, j))
+			}
+			if math.Abs(qr.get(i, j)-a.get(i, j)) > 1e-12 {
+				t.Errorf("(QR)[%d][%d] = %g, ожидалось %g", i, j, qr.get(i, j), a.get(i, j))
+			}
+		}
+	}
+}
+
+func TestSolveLinearSystemResidual(t *testing.T) {
+	a := testSystemMatrix()
+	want := []float64{1, -2, 0.5, 3}
+	b := a.mulVec(want)
+
+	var log bytes.Buffer
+	x, err := solveLinearSystem(a, b, &log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !vectorsClose(x, want, 1e-12) {
+		t.Errorf("решение %v, ожидалось %v", x, want)
+	}
+	if r := residualNorm(a, x, b); r > 1e-10 {
+		t.Errorf("невязка %.3e", r)
+	}
+	if !strings.HasPrefix(log.String(), "solveLinearSystem: n = 4, ||Ax - b|| = ") {
+		t.Errorf("вывод невязки %q", log.String())
+	}
+
+	// Без verbose ничего не выводится, а результат тот же
+	quiet, err := solveLinearSystem(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !vectorsClose(quiet, x, 0) {
+		t.Errorf("без вывода невязки решение %v, с выводом %v", quiet, x)
+	}
+}
+
+func TestResidualNorm(t *testing.T) {
+	a := matrixFrom([][]float64{{2, 0}, {0, 1}})
+	// Ax - b = (2 - 5, 4 - 0) = (-3, 4)
+	if r := residualNorm(a, []float64{1, 4}, []float64{5, 0}); r != 5 {
+		t.Errorf("||Ax - b|| = %g, ожидалось 5", r)
+	}
+}
+
+// matrixEquals сравнивает элементы матрицы m со строками want
+func matrixEquals(m *matrix, want [][]float64) bool {
+	if m.rows != len(want) {
+		return false
+	}
+	for i := range want {
+		if !vectorsClose(m.data[i], want[i], 0) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMatrixRowOperations(t *testing.T) {
+	m := matrixFrom([][]float64{{1, 2}, {3, 4}, {5, 6}})
+
+	m.scaleRow(1, -2)
+	if want := [][]float64{{1, 2}, {-6, -8}, {5, 6}}; !matrixEquals(m, want) {
+		t.Errorf("после scaleRow(1, -2): %v, ожидалось %v", m.data, want)
+	}
+
+	m.swapRows(0, 2)
+	if want := [][]float64{{5, 6}, {-6, -8}, {1, 2}}; !matrixEquals(m, want) {
+		t.Errorf("после swapRows(0, 2): %v, ожидалось %v", m.data, want)
+	}
+
+	m.addScaledRow(1, 2, 3)
+	if want := [][]float64{{5, 6}, {-3, -2}, {1, 2}}; !matrixEquals(m, want) {
+		t.Errorf("после addScaledRow(1, 2, 3): %v, ожидалось %v", m.data, want)
+	}
+
+	m.swapRows(1, 1)
+	if want := [][]float64{{5, 6}, {-3, -2}, {1, 2}}; !matrixEquals(m, want) {
+		t.Errorf("после swapRows(1, 1): %v, ожидалось %v", m.data, want)
+	}
+}
+
+func TestMatrixRowOperationsBounds(t *testing.T) {
+	m := newMatrix(2, 2)
+	expectPanic(t, "scaleRow(2)", func() { m.scaleRow(2, 1) })
+	expectPanic(t, "swapRows(-1, 0)", func() { m.swapRows(-1, 0) })
+	expectPanic(t, "swapRows(0, 2)", func() { m.swapRows(0, 2) })
+	expectPanic(t, "addScaledRow(0, 5)", func() { m.addScaledRow(0, 5, 1) })
+	expectPanic(t, "addScaledRow(3, 0)", func() { m.addScaledRow(3, 0, 1) })
+}
+
+func TestMatrixTranspose(t *testing.T) {
+	m := matrixFrom([][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+	mt := m.transpose()
+	if mt.rows != 3 || mt.cols != 2 {
+		t.Fatalf("размер %dx%d, ожидалось 3x2", mt.rows, mt.cols)
+	}
+	if want := [][]float64{{1, 4}, {2, 5}, {3, 6}}; !matrixEquals(mt, want) {
+		t.Errorf("transpose = %v, ожидалось %v", mt.data, want)
+	}
+
+	// Транспонированная матрица - новая, исходная не меняется
+	mt.set(0, 1, 100)
+	if m.get(1, 0) != 4 {
+		t.Error("изменение транспонированной матрицы затронуло исходную")
+	}
+	if !matrixEquals(m.transpose().transpose(), [][]float64{{1, 2, 3}, {4, 5, 6}}) {
+		t.Error("повторное транспонирование не вернуло исходную матрицу")
+	}
+}
+
+func TestMatrixIsSymmetric(t *testing.T) {
+	symmetric := matrixFrom([][]float64{
+		{4, 1, -2},
+		{1, 3, 0.5},
+		{-2, 0.5, 6},
+	})
+	if !symmetric.isSymmetric(0) {
+		t.Error("симметричная матрица не признана симметричной")
+	}
+
+	a := testSystemMatrix()
+	if a.isSymmetric(1e-12) {
+		t.Error("несимметричная матрица признана симметричной")
+	}
+	// A^T A симметрична с точностью до округления
+	if ata := a.transpose().mul(a); !ata.isSymmetric(1e-12) {
+		t.Errorf("A^T A не симметрична: %v", ata.data)
+	}
+
+	symmetric.set(0, 2, -2+1e-10)
+	if symmetric.isSymmetric(1e-12) || !symmetric.isSymmetric(1e-9) {
+		t.Error("допуск tol не учитывается")
+	}
+	if m := newMatrix(2, 3); m.isSymmetric(1) {
+		t.Error("неквадратная матрица признана симметричной")
+	}
+}
+
+func TestMatrixInverse(t *testing.T) {
+	// Нулевой элемент a(0, 0) требует перестановки строк
+	a := matrixFrom([][]float64{
+		{0, 2, 1},
+		{1, 1, 0},
+		{3, -1, 4},
+	})
+	inv, err := a.inverse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, product := range []*matrix{a.mul(inv), inv.mul(a)} {
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				want := 0.0
+				if i == j {
+					want = 1
+				}
+				if math.Abs(product.get(i, j)-want) > 1e-10 {
+					t.Errorf("(A A^-1)[%d][%d] = %g, ожидалось %g", i, j, product.get(i, j), want)
+				}
+			}
+		}
+	}
+
+	// Сверка с LU-решением: столбец A^-1 - решение системы A x = e(j)
+	lu, perm, err := luDecompose(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for j := 0; j < 3; j++ {
+		e := make([]float64, 3)
+		e[j] = 1
+		x := luSolve(lu, perm, e)
+		for i := range x {
+			if math.Abs(x[i]-inv.get(i, j)) > 1e-12 {
+				t.Errorf("A^-1[%d][%d] = %g, по LU %g", i, j, inv.get(i, j), x[i])
+			}
+		}
+	}
+}
+
+func TestMatrixInverseErrors(t *testing.T) {
+	singular := matrixFrom([][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+		{7, 8, 9},
+	})
+	if _, err := singular.inverse(); !errors.Is(err, errSingularMatrix) {
+		t.Errorf("для вырожденной матрицы получено %v, ожидалось errSingularMatrix", err)
+	}
+	if _, err := newMatrix(2, 3).inverse(); err == nil {
+		t.Error("ожидалась ошибка для неквадратной матрицы")
+	}
+}
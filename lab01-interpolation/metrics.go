@@ -0,0 +1,191 @@
+package main
+
+import "math"
+
+// relativeErrorEps - добавка к |f(x)| в знаменателе относительной ошибки, чтобы вблизи
+// корней функции не делить на ноль
+const relativeErrorEps = 1e-10
+
+// relativeError возвращает относительную ошибку |exact - approx| / (|exact| + relativeErrorEps)
+func relativeError(exact, approx float64) float64 {
+	return math.Abs(exact-approx) / (math.Abs(exact) + relativeErrorEps)
+}
+
+// runningStats накапливает статистику потока значений без их хранения: среднее и дисперсия
+// обновляются по алгоритму Уэлфорда, устойчивому к ошибкам округления, а сумма квадратов
+// и квадраты крайних значений дают L2-норму по формуле трапеций
+type runningStats struct {
+	n    int
+	mu   float64 // Текущее среднее
+	m2   float64 // Сумма квадратов отклонений от текущего среднего
+	maxV float64
+
+	sumSq           float64 // Сумма квадратов значений
+	firstSq, lastSq float64 // Квадраты первого и последнего значений
+}
+
+// add добавляет значение x
+func (s *runningStats) add(x float64) {
+	s.n++
+	delta := x - s.mu
+	s.mu += delta / float64(s.n)
+	s.m2 += delta * (x - s.mu)
+	if s.n == 1 || x > s.maxV {
+		s.maxV = x
+	}
+
+	s.sumSq += x * x
+	if s.n == 1 {
+		s.firstSq = x * x
+	}
+	s.lastSq = x * x
+}
+
+// count возвращает количество добавленных значений
+func (s *runningStats) count() int {
+	return s.n
+}
+
+// mean возвращает среднее значение (0 для пустой выборки)
+func (s *runningStats) mean() float64 {
+	return s.mu
+}
+
+// variance возвращает дисперсию выборки как генеральной совокупности (деление на n)
+func (s *runningStats) variance() float64 {
+	if s.n == 0 {
+		return 0
+	}
+	return s.m2 / float64(s.n)
+}
+
+// max возвращает наибольшее добавленное значение (0 для пустой выборки)
+func (s *runningStats) max() float64 {
+	return s.maxV
+}
+
+// rms возвращает среднеквадратичное значение sqrt(mean(x^2)) (0 для пустой выборки)
+func (s *runningStats) rms() float64 {
+	if s.n == 0 {
+		return 0
+	}
+	return math.Sqrt(s.sumSq / float64(s.n))
+}
+
+// l2 возвращает L2-норму функции, значения которой добавлялись в равноотстоящих точках
+// с шагом h: интеграл квадрата считается составной формулой трапеций (веса 1/2 у крайних
+// значений). Для одного значения возвращается его модуль, для пустой выборки - 0
+func (s *runningStats) l2(h float64) float64 {
+	if s.n < 2 {
+		return math.Sqrt(s.sumSq)
+	}
+	return math.Sqrt((s.sumSq - (s.firstSq+s.lastSq)/2) * h)
+}
+
+// percentile возвращает p-й процентиль (0 <= p <= 100) упорядоченной по возрастанию выборки
+// sorted с линейной интерполяцией между соседними рангами: значению с номером i соответствует
+// процентиль 100*i/(n-1). p вне [0, 100] приводится к ближайшей границе, для пустой выборки - NaN
+func percentile(sorted []float64, p float64) float64 {
+	n := len(sorted)
+	if n == 0 {
+		return math.NaN()
+	}
+
+	rank := math.Max(0, math.Min(100, p)) / 100 * float64(n-1)
+	lo := int(rank)
+	if lo >= n-1 {
+		return sorted[n-1]
+	}
+	frac := rank - float64(lo)
+	return sorted[lo] + frac*(sorted[lo+1]-sorted[lo])
+}
+
+// errorMetrics вычисляет по выборке ошибок в равноотстоящих точках максимальную ошибку,
+// среднеквадратичную ошибку и приближенную L2-норму (см. runningStats.l2). L2-норма считается
+// для единичного интервала; для интервала [a, b] ее нужно умножить на sqrt(b - a)
+func errorMetrics(samples []float64) (maxErr, rms, l2 float64) {
+	n := len(samples)
+	if n == 0 {
+		return 0, 0, 0
+	}
+
+	var stats runningStats
+	for _, e := range samples {
+		stats.add(math.Abs(e))
+	}
+
+	h := 1.0
+	if n > 1 {
+		h = 1 / float64(n-1)
+	}
+	return stats.max(), stats.rms(), stats.l2(h)
+}
+
+// leaveOneOutCV вычисляет оценку перекрестной проверки с исключением по одному:
+// для каждой точки интерполянт строится фабрикой по остальным точкам и сравнивается
+// со значением в исключенной точке. Возвращается средний квадрат ошибки предсказания.
+// Требует n построений интерполянта; крайние точки предсказываются экстраполяцией
+func leaveOneOutCV(points []point, splineFactory func([]point) Interpolator) float64 {
+	n := len(points)
+	if n < 2 {
+		return 0
+	}
+
+	sumSquares := 0.0
+	for i, held := range points {
+		// Новый срез на каждой итерации: интерполянты хранят ссылку на свои узлы
+		rest := make([]point, 0, n-1)
+		rest = append(rest, points[:i]...)
+		rest = append(rest, points[i+1:]...)
+
+		diff := splineFactory(rest).Evaluate(held.x) - held.y
+		sumSquares += diff * diff
+	}
+
+	return sumSquares / float64(n)
+}
+
+// Параметры detectRunge: доля отрезка у каждого конца, считающаяся приграничной,
+// порог отношения ошибок, уровень ошибки (относительно max|f|), ниже которого она
+// считается ошибкой округления, и количество точек выборки
+const (
+	rungeEdgeFraction = 0.1
+	rungeRatio        = 10.0
+	rungeNoise        = 1e-8
+	rungeSamples      = 1000
+)
+
+// detectRunge проверяет интерполяцию Лагранжа по узлам data на признак явления Рунге:
+// сравнивает максимальную ошибку в приграничных областях (по rungeEdgeFraction отрезка
+// [a, b] с каждой стороны) с максимальной ошибкой в центральной половине отрезка.
+// Возвращает отношение этих ошибок и признак того, что оно больше rungeRatio, а сама
+// ошибка у концов больше ошибки округления. Если в центре ошибка нулевая, отношение
+// равно +Inf (или 1, если ошибок нет вовсе)
+func detectRunge(data *interpolationData, f func(float64) float64) (bool, float64) {
+	width := data.b - data.a
+	mid := (data.a + data.b) / 2
+
+	edgeErr, centerErr, scale := 0.0, 0.0, 0.0
+	for _, x := range linspace(data.a, data.b, rungeSamples) {
+		y := f(x)
+		e := math.Abs(y - lagrangeInterpolation(data, x))
+		scale = math.Max(scale, math.Abs(y))
+		switch {
+		case x < data.a+rungeEdgeFraction*width || x > data.b-rungeEdgeFraction*width:
+			edgeErr = math.Max(edgeErr, e)
+		case math.Abs(x-mid) <= width/4:
+			centerErr = math.Max(centerErr, e)
+		}
+	}
+
+	var ratio float64
+	switch {
+	case centerErr > 0:
+		ratio = edgeErr / centerErr
+	case edgeErr > 0:
+		ratio = math.Inf(1)
+	default:
+		ratio = 1
+	}
+	return ratio > rungeRatio && edgeErr > rungeNoise*scale, ratio
+}
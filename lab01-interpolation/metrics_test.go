@@ -0,0 +1,233 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestErrorMetrics(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		samples         []float64
+		maxErr, rms, l2 float64
+	}{
+		{"пустая выборка", nil, 0, 0, 0},
+		{"одно значение", []float64{-2}, 2, 2, 2},
+		{"постоянная", []float64{0.5, 0.5, 0.5, 0.5}, 0.5, 0.5, 0.5},
+		{"два значения", []float64{3, -4}, 4, math.Sqrt(12.5), math.Sqrt(12.5)},
+		// Трапеции с шагом 1/4: (0/2 + 1 + 0 + 1 + 0/2) / 4 = 1/2
+		{"пила", []float64{0, 1, 0, -1, 0}, 1, math.Sqrt(0.4), math.Sqrt(0.5)},
+	} {
+		maxErr, rms, l2 := errorMetrics(tc.samples)
+		if math.Abs(maxErr-tc.maxErr) > 1e-15 || math.Abs(rms-tc.rms) > 1e-15 || math.Abs(l2-tc.l2) > 1e-15 {
+			t.Errorf("%s: errorMetrics = (%g, %g, %g), ожидалось (%g, %g, %g)",
+				tc.name, maxErr, rms, l2, tc.maxErr, tc.rms, tc.l2)
+		}
+	}
+}
+
+func TestErrorMetricsL2ApproximatesIntegral(t *testing.T) {
+	// Для e(t) = sin(2 pi t) на [0, 1] интеграл e^2 равен 1/2
+	samples := make([]float64, 1001)
+	for i := range samples {
+		samples[i] = math.Sin(2 * math.Pi * float64(i) / 1000)
+	}
+	_, _, l2 := errorMetrics(samples)
+	if math.Abs(l2-math.Sqrt(0.5)) > 1e-12 {
+		t.Errorf("L2-норма sin(2 pi t) = %.15g, ожидалось %.15g", l2, math.Sqrt(0.5))
+	}
+}
+
+func TestRelativeError(t *testing.T) {
+	if got := relativeError(2, 2.02); math.Abs(got-0.01) > 1e-9 {
+		t.Errorf("relativeError(2, 2.02) = %g, ожидалось 0.01", got)
+	}
+	if got := relativeError(-4, -3); math.Abs(got-0.25) > 1e-9 {
+		t.Errorf("relativeError(-4, -3) = %g, ожидалось 0.25", got)
+	}
+
+	// В корне функции знаменатель равен relativeErrorEps, а не нулю
+	if got := relativeError(0, 1e-12); math.IsInf(got, 0) || math.IsNaN(got) || math.Abs(got-0.01) > 1e-12 {
+		t.Errorf("relativeError(0, 1e-12) = %g, ожидалось 0.01", got)
+	}
+	if got := relativeError(0, 0); got != 0 {
+		t.Errorf("relativeError(0, 0) = %g", got)
+	}
+
+	// Вблизи корня тестовой функции на отрезке [2, 3] относительная ошибка конечна
+	root := 2.0
+	for hi := 3.0; hi-root > 1e-15; {
+		mid := (root + hi) / 2
+		if testFunction(mid) < 0 {
+			root = mid
+		} else {
+			hi = mid
+		}
+	}
+	if got := relativeError(testFunction(root), testFunction(root)+1e-6); math.IsInf(got, 0) || !(got > 1) {
+		t.Errorf("относительная ошибка вблизи корня %g, ожидалось конечное значение больше 1", got)
+	}
+}
+
+func TestLeaveOneOutCV(t *testing.T) {
+	data, err := createGrid(-1, 3, 10, testCubic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	factory := func(build func(*interpolationData) (*cubicSpline, error)) func([]point) Interpolator {
+		return func(points []point) Interpolator {
+			spline, err := build(&interpolationData{points: points})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return newSplineInterpolator("сплайн", spline)
+		}
+	}
+
+	// Сплайн not-a-knot восстанавливает кубический полином по любым своим узлам
+	notAKnot := leaveOneOutCV(data.points, factory(newNotAKnotSpline))
+	if notAKnot > 1e-20 {
+		t.Errorf("CV сплайна not-a-knot на кубическом полиноме %.3e, ожидался ноль", notAKnot)
+	}
+	natural := leaveOneOutCV(data.points, factory(newCubicSpline))
+	if !(natural > 1e6*notAKnot && natural > 0) {
+		t.Errorf("CV естественного сплайна %.3e не больше CV сплайна not-a-knot %.3e", natural, notAKnot)
+	}
+
+	if got := leaveOneOutCV(data.points[:1], factory(newCubicSpline)); got != 0 {
+		t.Errorf("CV по одной точке %g, ожидался 0", got)
+	}
+}
+
+func TestRunningStatsMatchesBatch(t *testing.T) {
+	values := []float64{0.3, 1.7, -2.4, 5.1, 0.02, 3.3, -0.8, 4.4, 2.2, 1e-3}
+
+	var stats runningStats
+	for _, v := range values {
+		stats.add(v)
+	}
+
+	n := float64(len(values))
+	mean, sumSq, maxV := 0.0, 0.0, math.Inf(-1)
+	for _, v := range values {
+		mean += v / n
+		sumSq += v * v
+		maxV = math.Max(maxV, v)
+	}
+	variance := 0.0
+	for _, v := range values {
+		variance += (v - mean) * (v - mean) / n
+	}
+	h := 0.25
+	trapezoid := h * (sumSq - (values[0]*values[0]+values[len(values)-1]*values[len(values)-1])/2)
+
+	for _, c := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"mean", stats.mean(), mean},
+		{"variance", stats.variance(), variance},
+		{"max", stats.max(), maxV},
+		{"rms", stats.rms(), math.Sqrt(sumSq / n)},
+		{"l2", stats.l2(h), math.Sqrt(trapezoid)},
+	} {
+		if math.Abs(c.got-c.want) > 1e-12 {
+			t.Errorf("%s = %.15g, ожидалось %.15g", c.name, c.got, c.want)
+		}
+	}
+	if stats.count() != len(values) {
+		t.Errorf("count = %d, ожидалось %d", stats.count(), len(values))
+	}
+}
+
+func TestCompareInterpolationsL2MatchesErrorMetrics(t *testing.T) {
+	methods := testInterpolators(t, 8)
+	result := compareInterpolations(methods, 1, 5, testFunction, defaultTableFormat())
+
+	// Та же выборка из 100 точек, что и в compareInterpolations
+	xs := linspace(1, 5, 100)
+	for k, m := range methods {
+		errs := make([]float64, len(xs))
+		for i, x := range xs {
+			errs[i] = testFunction(x) - m.Evaluate(x)
+		}
+		maxErr, rms, l2 := errorMetrics(errs)
+		l2 *= math.Sqrt(5 - 1)
+
+		e := result.errors[k]
+		if math.Abs(e.maxErr-maxErr) > 1e-15 || math.Abs(e.rms-rms) > 1e-12*rms || math.Abs(e.l2-l2) > 1e-12*l2 {
+			t.Errorf("%s: (%g, %g, %g), по errorMetrics (%g, %g, %g)", e.name, e.maxErr, e.rms, e.l2, maxErr, rms, l2)
+		}
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	// Значения 0, 1, ..., 100: p-й процентиль равен p
+	uniform := make([]float64, 101)
+	for i := range uniform {
+		uniform[i] = float64(i)
+	}
+	for _, p := range []float64{0, 50, 90, 99, 100, 37.5} {
+		if got := percentile(uniform, p); math.Abs(got-p) > 1e-12 {
+			t.Errorf("percentile(0..100, %g) = %g", p, got)
+		}
+	}
+
+	for _, tc := range []struct {
+		sorted  []float64
+		p, want float64
+	}{
+		{[]float64{1, 2, 3, 4}, 50, 2.5},
+		{[]float64{1, 2, 3, 4}, 90, 3.7}, // ранг 2.7
+		{[]float64{1, 2, 3, 4}, -10, 1},
+		{[]float64{1, 2, 3, 4}, 150, 4},
+		{[]float64{5}, 99, 5},
+		// Один выброс не влияет на медиану и 90-й процентиль, в отличие от максимума
+		{[]float64{0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 100}, 90, 0.1},
+	} {
+		if got := percentile(tc.sorted, tc.p); math.Abs(got-tc.want) > 1e-12 {
+			t.Errorf("percentile(%v, %g) = %g, ожидалось %g", tc.sorted, tc.p, got, tc.want)
+		}
+	}
+	if got := percentile(nil, 50); !math.IsNaN(got) {
+		t.Errorf("percentile(nil, 50) = %g, ожидалось NaN", got)
+	}
+}
+
+func TestComparePercentilesOrdered(t *testing.T) {
+	result := compareInterpolations(testInterpolators(t, 10), 1, 5, testFunction, defaultTableFormat())
+	for _, e := range result.errors {
+		if !(0 <= e.p50 && e.p50 <= e.p90 && e.p90 <= e.p99 && e.p99 <= e.maxErr) {
+			t.Errorf("%s: медиана %g, P90 %g, P99 %g, максимум %g", e.name, e.p50, e.p90, e.p99, e.maxErr)
+		}
+	}
+}
+
+func TestDetectRunge(t *testing.T) {
+	for _, n := range []int{10, 15, 20} {
+		uniform, err := createGrid(-1, 1, n, rungeFunction)
+		if err != nil {
+			t.Fatal(err)
+		}
+		chebyshev, err := createChebyshevGrid(-1, 1, n, rungeFunction)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if runge, ratio := detectRunge(uniform, rungeFunction); !runge || !(ratio > rungeRatio) {
+			t.Errorf("N = %d, равномерные узлы: признак %v, отношение ошибок %g", n, runge, ratio)
+		}
+		if runge, ratio := detectRunge(chebyshev, rungeFunction); runge || ratio > 1 {
+			t.Errorf("N = %d, узлы Чебышева: признак %v, отношение ошибок %g", n, runge, ratio)
+		}
+	}
+
+	// Кубический полином восстанавливается точно: отношение ошибок округления не учитывается
+	data, err := createGrid(-1, 1, 10, testCubic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runge, ratio := detectRunge(data, testCubic); runge {
+		t.Errorf("кубический полином: признак явления Рунге при отношении ошибок %g", ratio)
+	}
+}
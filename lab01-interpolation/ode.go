@@ -0,0 +1,107 @@
+package main
+
+import "math"
+
+// eulerMethod решает задачу Коши y' = f(t, y), y(t0) = y0 явным методом Эйлера
+// y(k+1) = y(k) + h*f(t(k), y(k)) с постоянным шагом h. Возвращает траекторию из steps+1
+// точек (t, y), начиная с (t0, y0). Глобальная погрешность O(h)
+func eulerMethod(f func(t, y float64) float64, t0, y0, h float64, steps int) []point {
+	trajectory := make([]point, 0, steps+1)
+	t, y := t0, y0
+	trajectory = append(trajectory, point{x: t, y: y})
+
+	for k := 1; k <= steps; k++ {
+		y += h * f(t, y)
+		t = t0 + float64(k)*h
+		trajectory = append(trajectory, point{x: t, y: y})
+	}
+
+	return trajectory
+}
+
+// rk4 решает задачу Коши y' = f(t, y), y(t0) = y0 классическим методом Рунге-Кутты
+// четвертого порядка с постоянным шагом h. Возвращает траекторию из steps+1 точек (t, y),
+// начиная с (t0, y0). Глобальная погрешность O(h^4)
+func rk4(f func(t, y float64) float64, t0, y0, h float64, steps int) []point {
+	trajectory := make([]point, 0, steps+1)
+	t, y := t0, y0
+	trajectory = append(trajectory, point{x: t, y: y})
+
+	for k := 1; k <= steps; k++ {
+		k1 := f(t, y)
+		k2 := f(t+h/2, y+h/2*k1)
+		k3 := f(t+h/2, y+h/2*k2)
+		k4 := f(t+h, y+h*k3)
+
+		y += h / 6 * (k1 + 2*k2 + 2*k3 + k4)
+		t = t0 + float64(k)*h
+		trajectory = append(trajectory, point{x: t, y: y})
+	}
+
+	return trajectory
+}
+
+// Параметры управления шагом rk45
+const (
+	rk45Safety    = 0.9    // Коэффициент запаса при выборе нового шага
+	rk45MinFactor = 0.2    // Наибольшее уменьшение шага за одну попытку
+	rk45MaxFactor = 5.0    // Наибольшее увеличение шага за один шаг
+	rk45MaxSteps  = 100000 // Ограничение на количество принятых шагов
+)
+
+// rk45 решает задачу Коши y' = f(t, y), y(t0) = y0 на [t0, tEnd] вложенным методом
+// Дормана-Принса 5(4) с автоматическим выбором шага: разность решений пятого и четвертого
+// порядков оценивает локальную погрешность, шаг принимается, если она не больше tol, и
+// меняется пропорционально (tol/err)^(1/5). Продолжается решение пятого порядка.
+// Возвращает траекторию с переменным шагом, последняя точка - tEnd. При tEnd <= t0 или
+// tol <= 0 возвращается только начальная точка; после rk45MaxSteps шагов решение обрывается
+func rk45(f func(t, y float64) float64, t0, y0, tEnd, tol float64) []point {
+	trajectory := []point{{x: t0, y: y0}}
+	if !(tEnd > t0) || !(tol > 0) {
+		return trajectory
+	}
+
+	t, y := t0, y0
+	h := (tEnd - t0) / 100
+	minStep := 1e-12 * (tEnd - t0)
+	k1 := f(t, y)
+
+	for steps := 0; t < tEnd && steps < rk45MaxSteps; {
+		last := t+h >= tEnd
+		if last {
+			h = tEnd - t
+		}
+
+		k2 := f(t+h/5, y+h*(k1/5))
+		k3 := f(t+3*h/10, y+h*(3*k1/40+9*k2/40))
+		k4 := f(t+4*h/5, y+h*(44*k1/45-56*k2/15+32*k3/9))
+		k5 := f(t+8*h/9, y+h*(19372*k1/6561-25360*k2/2187+64448*k3/6561-212*k4/729))
+		k6 := f(t+h, y+h*(9017*k1/3168-355*k2/33+46732*k3/5247+49*k4/176-5103*k5/18656))
+		y5 := y + h*(35*k1/384+500*k3/1113+125*k4/192-2187*k5/6784+11*k6/84)
+		k7 := f(t+h, y5)
+
+		// Разность решений пятого и четвертого порядков
+		errEstimate := math.Abs(h * (71*k1/57600 - 71*k3/16695 + 71*k4/1920 - 17253*k5/339200 + 22*k6/525 - k7/40))
+
+		factor := rk45MaxFactor
+		if errEstimate > 0 {
+			factor = math.Min(rk45MaxFactor, math.Max(rk45MinFactor, rk45Safety*math.Pow(tol/errEstimate, 0.2)))
+		}
+
+		// Шаг минимальной длины принимается и без выполнения точности, чтобы не зациклиться
+		if errEstimate <= tol || h <= minStep {
+			if last {
+				t = tEnd
+			} else {
+				t += h
+			}
+			y = y5
+			k1 = k7 // Последняя стадия совпадает с первой стадией следующего шага
+			trajectory = append(trajectory, point{x: t, y: y})
+			steps++
+		}
+		h = math.Max(h*factor, minStep)
+	}
+
+	return trajectory
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// exponentialGrowth - правая часть задачи y' = y с решением y = e^t при y(0) = 1
+func exponentialGrowth(_, y float64) float64 {
+	return y
+}
+
+func TestRK4MoreAccurateThanEuler(t *testing.T) {
+	const h, steps = 0.1, 10
+	euler := eulerMethod(exponentialGrowth, 0, 1, h, steps)
+	runge := rk4(exponentialGrowth, 0, 1, h, steps)
+	if len(euler) != steps+1 || len(runge) != steps+1 {
+		t.Fatalf("%d и %d точек траектории, ожидалось %d", len(euler), len(runge), steps+1)
+	}
+	if euler[0] != (point{0, 1}) || runge[0] != (point{0, 1}) {
+		t.Errorf("начальные точки %v и %v, ожидалось (0, 1)", euler[0], runge[0])
+	}
+
+	end := len(euler) - 1
+	if euler[end].x != 1 || runge[end].x != 1 {
+		t.Fatalf("конечные точки t = %g и %g, ожидалось 1", euler[end].x, runge[end].x)
+	}
+	eulerErr := math.Abs(euler[end].y - math.E)
+	rk4Err := math.Abs(runge[end].y - math.E)
+	// Погрешности около 0.12 и 2e-6
+	if !(rk4Err < 1e-5 && rk4Err < 1e-4*eulerErr) {
+		t.Errorf("ошибка при t = 1: Эйлер %.3e, RK4 %.3e", eulerErr, rk4Err)
+	}
+}
+
+func TestRK45FastTransient(t *testing.T) {
+	// y' = -50(y - cos t), y(0) = 0: за время порядка 0.1 решение выходит на медленную кривую,
+	// близкую к cos t
+	f := func(t, y float64) float64 { return -50 * (y - math.Cos(t)) }
+	exact := func(t float64) float64 {
+		return (2500*math.Cos(t)+50*math.Sin(t))/2501 - 2500.0/2501*math.Exp(-50*t)
+	}
+	maxTrajectoryError := func(trajectory []point) float64 {
+		maxErr := 0.0
+		for _, p := range trajectory {
+			maxErr = math.Max(maxErr, math.Abs(p.y-exact(p.x)))
+		}
+		return maxErr
+	}
+
+	const tol, tEnd = 1e-6, 3.0
+	adaptive := rk45(f, 0, 0, tEnd, tol)
+	steps := len(adaptive) - 1
+	if last := adaptive[steps]; last.x != tEnd {
+		t.Fatalf("траектория заканчивается в t = %g, ожидалось %g", last.x, tEnd)
+	}
+	adaptiveErr := maxTrajectoryError(adaptive)
+	if adaptiveErr > tol {
+		t.Errorf("максимальная ошибка rk45 %.3e больше tol = %g", adaptiveErr, tol)
+	}
+
+	// Шаги мельче всего в переходном слое
+	maxStep := 0.0
+	for i := 1; i <= steps; i++ {
+		maxStep = math.Max(maxStep, adaptive[i].x-adaptive[i-1].x)
+	}
+	if first := adaptive[1].x - adaptive[0].x; !(first < maxStep/5) {
+		t.Errorf("первый шаг %g, наибольший %g", first, maxStep)
+	}
+
+	// RK4 с постоянным шагом и вчетверо большим числом шагов все равно менее точен
+	fixedSteps := 4 * steps
+	fixedErr := maxTrajectoryError(rk4(f, 0, 0, tEnd/float64(fixedSteps), fixedSteps))
+	if !(fixedErr > adaptiveErr) {
+		t.Errorf("rk45: %d шагов, ошибка %.3e; rk4: %d шагов, ошибка %.3e", steps, adaptiveErr, fixedSteps, fixedErr)
+	}
+
+	if got := rk45(f, 0, 0, 0, tol); len(got) != 1 {
+		t.Errorf("при tEnd = t0 %d точек, ожидалась одна", len(got))
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// parametricSpline представляет плоскую кривую (x(t), y(t)), где каждая координата -
+// натуральный кубический сплайн по параметру t, равному накопленной длине хорд.
+// Позволяет интерполировать замкнутые и самопересекающиеся кривые
+type parametricSpline struct {
+	x *cubicSpline
+	y *cubicSpline
+}
+
+// newParametricSpline создает параметрический сплайн через точки points в порядке их следования.
+// Точки не обязаны быть упорядочены по x, но соседние точки не должны совпадать
+func newParametricSpline(points []point) (*parametricSpline, error) {
+	n := len(points)
+
+	// Параметризация по накопленной длине хорд
+	xt := make([]point, n)
+	yt := make([]point, n)
+	t := 0.0
+	for i, p := range points {
+		if i > 0 {
+			t += math.Hypot(p.x-points[i-1].x, p.y-points[i-1].y)
+		}
+		xt[i] = point{x: t, y: p.x}
+		yt[i] = point{x: t, y: p.y}
+	}
+
+	x, err := newCubicSpline(&interpolationData{points: xt, a: 0, b: t, n: n - 1})
+	if err != nil {
+		return nil, fmt.Errorf("параметризация по длине хорд: %w", err)
+	}
+	y, err := newCubicSpline(&interpolationData{points: yt, a: 0, b: t, n: n - 1})
+	if err != nil {
+		return nil, fmt.Errorf("параметризация по длине хорд: %w", err)
+	}
+
+	return &parametricSpline{x: x, y: y}, nil
+}
+
+// evaluate вычисляет точку кривой при значении параметра t из [0, length()]
+func (ps *parametricSpline) evaluate(t float64) point {
+	return point{x: ps.x.evaluate(t), y: ps.y.evaluate(t)}
+}
+
+// length возвращает конечное значение параметра - суммарную длину хорд
+func (ps *parametricSpline) length() float64 {
+	_, hi := ps.x.domain()
+	return hi
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParametricSplineCircle(t *testing.T) {
+	const n = 32
+	points := make([]point, n+1)
+	for i := range points {
+		phi := 2 * math.Pi * float64(i) / n
+		points[i] = point{x: math.Cos(phi), y: math.Sin(phi)}
+	}
+	spline, err := newParametricSpline(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Кривая проходит через исходные точки
+	chord := 2 * math.Sin(math.Pi/n)
+	for i, p := range points {
+		q := spline.evaluate(float64(i) * chord)
+		if math.Hypot(q.x-p.x, q.y-p.y) > 1e-12 {
+			t.Errorf("точка %d: %v, ожидалось %v", i, q, p)
+		}
+	}
+
+	// Между точками кривая остается близко к окружности. Наибольшее отклонение - у концов
+	// параметра, где естественные граничные условия не соответствуют кривизне окружности
+	length := spline.length()
+	edgeDev, interiorDev := 0.0, 0.0
+	for _, s := range linspace(0, length, 1000) {
+		q := spline.evaluate(s)
+		dev := math.Abs(math.Hypot(q.x, q.y) - 1)
+		if s < length/4 || s > 3*length/4 {
+			edgeDev = math.Max(edgeDev, dev)
+		} else {
+			interiorDev = math.Max(interiorDev, dev)
+		}
+	}
+	if edgeDev > 5e-3 || interiorDev > 1e-5 {
+		t.Errorf("отклонение от единичной окружности у концов %.3e, в середине %.3e", edgeDev, interiorDev)
+	}
+}
+
+func TestParametricSplineRepeatedPoint(t *testing.T) {
+	if _, err := newParametricSpline([]point{{0, 0}, {1, 1}, {1, 1}, {2, 0}}); err == nil {
+		t.Error("ожидалась ошибка для совпадающих соседних точек")
+	}
+}
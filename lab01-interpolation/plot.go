@@ -1,38 +1,308 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"html"
 	"math"
 	"os"
+	"strconv"
 	"strings"
 )
 
+// htmlOptions задает параметры построения HTML отчета
+type htmlOptions struct {
+	numPoints      int  // Количество точек, в которых строятся графики (не меньше 2)
+	showErrorChart bool // Добавлять ли график ошибок
+}
+
+// defaultHTMLOptions возвращает параметры по умолчанию: 201 точка и график ошибок
+func defaultHTMLOptions() htmlOptions {
+	return htmlOptions{numPoints: 201, showErrorChart: true}
+}
+
+// derivativeStep - шаг центральной разности для производной исходной функции на графике
+const derivativeStep = 1e-5
+
 // generateHTML создает HTML файл с графиками
-func generateHTML(uniformData, chebyshevData *interpolationData, testFunc func(float64) float64, filename string) error {
-	spline := newCubicSpline(uniformData)
+func generateHTML(uniformData, chebyshevData *interpolationData, testFunc func(float64) float64, filename string, opts htmlOptions) error {
+	if err := checkPlotPoints(opts.numPoints); err != nil {
+		return err
+	}
+	spline, err := newCubicSpline(uniformData)
+	if err != nil {
+		return err
+	}
 
-	// Генерируем данные для графиков
-	numPoints := 200
-	step := (uniformData.b - uniformData.a) / float64(numPoints)
+	// Точки графика и значения сплайна в них берутся из выборки сплайна по его отрезку
+	xs, splineValues := spline.sample(opts.numPoints)
+	samples := sampleFunctionAt(testFunc, xs)
+	samples.spline = splineValues
+	return writeChartsPage(uniformData, chebyshevData, samples, filename, opts.showErrorChart)
+}
+
+// generateHTMLFromSamples создает HTML файл с графиками, в котором исходная функция задана
+// не формулой, а значениями trueVals в строго возрастающих точках xs (например, измерениями).
+// Производная исходной функции оценивается по этим значениям разделенными разностями
+func generateHTMLFromSamples(xs, trueVals []float64, uniformData, chebyshevData *interpolationData, filename string) error {
+	if len(xs) != len(trueVals) {
+		return fmt.Errorf("количество точек %d не совпадает с количеством значений %d", len(xs), len(trueVals))
+	}
+
+	points := make([]point, len(xs))
+	for i := range xs {
+		points[i] = point{x: xs[i], y: trueVals[i]}
+	}
+	if err := validateSplinePoints(points); err != nil {
+		return fmt.Errorf("значения функции: %w", err)
+	}
+
+	samples := &plotSamples{x: xs, y: trueVals, dy: nodeDerivatives(points)}
+	return writeChartsPage(uniformData, chebyshevData, samples, filename, defaultHTMLOptions().showErrorChart)
+}
+
+// writeChartsPage записывает в файл страницу с графиками одного набора данных
+func writeChartsPage(uniformData, chebyshevData *interpolationData, samples *plotSamples, filename string, showErrorChart bool) error {
+	markup, script, err := renderCharts(uniformData, chebyshevData, samples, showErrorChart, "")
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("    <h1>Результаты интерполяции (N = %d узлов)</h1>\n    \n%s", uniformData.n, markup)
+	return os.WriteFile(filename, []byte(renderPage(body, script)), 0644)
+}
+
+// plotSamples - значения исходной функции и ее производной в точках графика
+type plotSamples struct {
+	x, y, dy []float64
+	spline   []float64 // Значения сплайна в точках x, если уже вычислены
+}
+
+// checkPlotPoints проверяет количество точек графика
+func checkPlotPoints(numPoints int) error {
+	if numPoints < 2 {
+		return fmt.Errorf("количество точек графика должно быть не меньше 2, получено %d", numPoints)
+	}
+	return nil
+}
+
+// sampleForPlot вычисляет testFunc и ее производную (центральной разностью) в numPoints
+// равноотстоящих точках [a, b]
+func sampleForPlot(testFunc func(float64) float64, a, b float64, numPoints int) (*plotSamples, error) {
+	if err := checkPlotPoints(numPoints); err != nil {
+		return nil, err
+	}
+	return sampleFunctionAt(testFunc, linspace(a, b, numPoints)), nil
+}
+
+// sampleFunctionAt вычисляет testFunc и ее производную (центральной разностью) в точках xs
+func sampleFunctionAt(testFunc func(float64) float64, xs []float64) *plotSamples {
+	samples := &plotSamples{x: xs}
+	for _, x := range xs {
+		samples.y = append(samples.y, testFunc(x))
+		samples.dy = append(samples.dy, centralDifference(testFunc, x, derivativeStep))
+	}
+	return samples
+}
+
+// namedDataset - функция и ее сетки для отдельного раздела generateHTMLMulti
+type namedDataset struct {
+	name          string
+	testFunc      func(float64) float64
+	uniformData   *interpolationData
+	chebyshevData *interpolationData
+}
+
+// generateHTMLMulti создает один HTML файл с разделом графиков для каждого набора данных,
+// чтобы сравнивать интерполяцию нескольких функций на одной странице
+func generateHTMLMulti(datasets []*namedDataset, filename string) error {
+	if len(datasets) == 0 {
+		return errors.New("не задано ни одного набора данных")
+	}
+
+	var body, script strings.Builder
+	body.WriteString("    <h1>Сравнение интерполяции нескольких функций</h1>\n")
+	for i, ds := range datasets {
+		opts := defaultHTMLOptions()
+		samples, err := sampleForPlot(ds.testFunc, ds.uniformData.a, ds.uniformData.b, opts.numPoints)
+		if err != nil {
+			return fmt.Errorf("%s: %w", ds.name, err)
+		}
+		markup, sectionScript, err := renderCharts(ds.uniformData, ds.chebyshevData, samples, opts.showErrorChart, fmt.Sprintf("f%d-", i))
+		if err != nil {
+			return fmt.Errorf("%s: %w", ds.name, err)
+		}
 
-	var xValues, originalValues, lagrangeUniformValues, lagrangeChebyshevValues, splineValues []float64
+		fmt.Fprintf(&body, "    \n    <h2 class=\"section-title\">%s (N = %d узлов)</h2>\n%s",
+			html.EscapeString(ds.name), ds.uniformData.n, markup)
+		// Каждый раздел - отдельный блок, чтобы константы ctx разных разделов не конфликтовали
+		fmt.Fprintf(&script, "        {%s        }\n", sectionScript)
+	}
+
+	return os.WriteFile(filename, []byte(renderPage(body.String(), script.String())), 0644)
+}
+
+// plotDerivativeError создает HTML файл с погрешностью центральной разности centralDifference
+// относительно аналитической производной df: на первом графике - погрешность по x на [a, b]
+// для каждого шага из hs, на втором - максимальная погрешность в зависимости от h в
+// логарифмическом масштабе. При больших h преобладает ошибка усечения O(h^2), при малых -
+// ошибка округления O(eps/h), поэтому у второго графика есть минимум в оптимальном шаге
+func plotDerivativeError(f, df func(float64) float64, a, b float64, hs []float64, filename string) error {
+	if len(hs) == 0 {
+		return errors.New("не задано ни одного шага")
+	}
+	for _, h := range hs {
+		if !(h > 0) {
+			return fmt.Errorf("шаг должен быть положительным, получено %g", h)
+		}
+	}
+	if !(b > a) {
+		return fmt.Errorf("левая граница %g должна быть меньше правой %g", a, b)
+	}
+
+	numPoints := defaultHTMLOptions().numPoints
+	xs := linspace(a, b, numPoints)
+
+	// Набор данных первого графика и максимальная погрешность для каждого шага
+	var datasets strings.Builder
+	maxErrors := make([]float64, len(hs))
+	for k, h := range hs {
+		errs := make([]float64, numPoints)
+		for i, x := range xs {
+			errs[i] = math.Abs(centralDifference(f, x, h) - df(x))
+			maxErrors[k] = math.Max(maxErrors[k], errs[i])
+		}
+
+		if k > 0 {
+			datasets.WriteString(", ")
+		}
+		fmt.Fprintf(&datasets, `{
+                    label: 'h = %g',
+                    data: %s,
+                    borderColor: 'hsl(%d, 70%%, 50%%)',
+                    borderWidth: 2,
+                    pointRadius: 0,
+                    tension: 0.1
+                }`, h, floatSliceToJS(errs), 360*k/len(hs))
+	}
+
+	body := `    <h1>Погрешность численного дифференцирования</h1>
+    <div class="charts-container">
+        <div class="chart-container full-width">
+            <h2>Погрешность центральной разности на отрезке</h2>
+            <canvas id="derivativeErrorChart"></canvas>
+        </div>
+        <div class="chart-container full-width">
+            <h2>Максимальная погрешность в зависимости от шага</h2>
+            <canvas id="stepErrorChart"></canvas>
+        </div>
+    </div>`
+
+	script := fmt.Sprintf(`
+        // Погрешность по x для каждого шага
+        const ctx1 = document.getElementById('derivativeErrorChart').getContext('2d');
+        new Chart(ctx1, {
+            type: 'line',
+            data: {
+                labels: %s,
+                datasets: [%s]
+            },
+            options: {
+                responsive: true,
+                maintainAspectRatio: false,
+                plugins: {
+                    legend: { position: 'top' }
+                },
+                scales: {
+                    x: { title: { display: true, text: 'x' } },
+                    y: {
+                        type: 'logarithmic',
+                        title: { display: true, text: 'Ошибка (log)' }
+                    }
+                }
+            }
+        });
+
+        // Максимальная погрешность в зависимости от шага
+        const ctx2 = document.getElementById('stepErrorChart').getContext('2d');
+        new Chart(ctx2, {
+            type: 'scatter',
+            data: {
+                datasets: [{
+                    label: 'max |D(h) - f\'(x)|',
+                    data: %s.map((h, i) => ({x: h, y: %s[i]})),
+                    borderColor: 'rgb(255, 99, 132)',
+                    backgroundColor: 'rgba(255, 99, 132, 0.8)',
+                    pointRadius: 5,
+                    showLine: true
+                }]
+            },
+            options: {
+                responsive: true,
+                maintainAspectRatio: false,
+                plugins: {
+                    legend: { position: 'top' }
+                },
+                scales: {
+                    x: {
+                        type: 'logarithmic',
+                        title: { display: true, text: 'h (log)' }
+                    },
+                    y: {
+                        type: 'logarithmic',
+                        title: { display: true, text: 'Ошибка (log)' }
+                    }
+                }
+            }
+        });
+`, floatSliceToJS(xs), datasets.String(), floatSliceToJS(hs), floatSliceToJS(maxErrors))
+
+	return os.WriteFile(filename, []byte(renderPage(body, script)), 0644)
+}
+
+// renderCharts строит разметку контейнеров графиков и скрипт Chart.js для одного набора данных.
+// Префикс id добавляется к идентификаторам элементов canvas, чтобы на одной странице
+// могли находиться графики нескольких наборов
+func renderCharts(uniformData, chebyshevData *interpolationData, samples *plotSamples, showErrorChart bool, id string) (markup, script string, err error) {
+	spline, err := newCubicSpline(uniformData)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Генерируем данные для графиков
+	var xValues, originalValues, lagrangeUniformValues, lagrangeChebyshevValues []float64
 	var lagrangeUniformErrors, lagrangeChebyshevErrors, splineErrors []float64
+	var lagrangeUniformRelErrors, lagrangeChebyshevRelErrors, splineRelErrors []float64
+	var splineDerivatives, trueDerivatives []float64
+
+	// Веса барицентрической формулы вычисляются один раз: O(n) на точку графика вместо O(n^2)
+	uniformWeights := barycentricWeights(uniformData.points)
+	chebyshevWeights := barycentricWeights(chebyshevData.points)
+
+	// Значения сплайна вычисляются одним проходом по отрезкам, если не переданы вместе с точками
+	splineValues := samples.spline
+	if splineValues == nil {
+		splineValues = spline.evaluateBatch(samples.x)
+	}
 
-	for i := 0; i <= numPoints; i++ {
-		x := uniformData.a + float64(i)*step
-		original := testFunc(x)
-		lagrangeUniform := lagrangeInterpolation(uniformData, x)
-		lagrangeChebyshev := lagrangeInterpolation(chebyshevData, x)
-		splineVal := spline.evaluate(x)
+	for i, x := range samples.x {
+		original := samples.y[i]
+		lagrangeUniform := barycentricInterpolation(uniformData.points, uniformWeights, x)
+		lagrangeChebyshev := barycentricInterpolation(chebyshevData.points, chebyshevWeights, x)
+		splineVal := splineValues[i]
 
 		xValues = append(xValues, x)
 		originalValues = append(originalValues, original)
 		lagrangeUniformValues = append(lagrangeUniformValues, lagrangeUniform)
 		lagrangeChebyshevValues = append(lagrangeChebyshevValues, lagrangeChebyshev)
-		splineValues = append(splineValues, splineVal)
 		lagrangeUniformErrors = append(lagrangeUniformErrors, math.Abs(original-lagrangeUniform))
 		lagrangeChebyshevErrors = append(lagrangeChebyshevErrors, math.Abs(original-lagrangeChebyshev))
 		splineErrors = append(splineErrors, math.Abs(original-splineVal))
+		lagrangeUniformRelErrors = append(lagrangeUniformRelErrors, relativeError(original, lagrangeUniform))
+		lagrangeChebyshevRelErrors = append(lagrangeChebyshevRelErrors, relativeError(original, lagrangeChebyshev))
+		splineRelErrors = append(splineRelErrors, relativeError(original, splineVal))
+		splineDerivatives = append(splineDerivatives, spline.evaluateDerivative(x))
+		trueDerivatives = append(trueDerivatives, samples.dy[i])
 	}
 
 	// Конвертируем данные в JSON формат
@@ -43,7 +313,12 @@ func generateHTML(uniformData, chebyshevData *interpolationData, testFunc func(f
 	splineValuesStr := floatSliceToJS(splineValues)
 	lagrangeUniformErrorsStr := floatSliceToJS(lagrangeUniformErrors)
 	lagrangeChebyshevErrorsStr := floatSliceToJS(lagrangeChebyshevErrors)
+	splineDerivativesStr := floatSliceToJS(splineDerivatives)
+	trueDerivativesStr := floatSliceToJS(trueDerivatives)
 	splineErrorsStr := floatSliceToJS(splineErrors)
+	lagrangeUniformRelErrorsStr := floatSliceToJS(lagrangeUniformRelErrors)
+	lagrangeChebyshevRelErrorsStr := floatSliceToJS(lagrangeChebyshevRelErrors)
+	splineRelErrorsStr := floatSliceToJS(splineRelErrors)
 
 	// Данные узлов (равномерные)
 	var uniformNodesX, uniformNodesY []float64
@@ -63,78 +338,114 @@ func generateHTML(uniformData, chebyshevData *interpolationData, testFunc func(f
 	chebyshevNodesXStr := floatSliceToJS(chebyshevNodesX)
 	chebyshevNodesYStr := floatSliceToJS(chebyshevNodesY)
 
-	htmlContent := fmt.Sprintf(`<!DOCTYPE html>
-<html lang="ru">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Результаты интерполяции</title>
-    <script src="https://cdnjs.cloudflare.com/ajax/libs/Chart.js/3.9.1/chart.min.js"></script>
-    <style>
-        body {
-            font-family: Arial, sans-serif;
-            max-width: 1600px;
-            margin: 0 auto;
-            padding: 20px;
-            background: #f5f5f5;
-        }
-        h1 {
-            text-align: center;
-            color: #333;
-        }
-        .charts-container {
-            display: grid;
-            grid-template-columns: 1fr 1fr;
-            gap: 20px;
-            margin-bottom: 20px;
-        }
-        .chart-container {
-            background: white;
-            padding: 20px;
-            border-radius: 8px;
-            box-shadow: 0 2px 4px rgba(0,0,0,0.1);
-        }
-        .full-width {
-            grid-column: 1 / -1;
-        }
-        canvas {
-            max-width: 100%%;
-            height: 400px !important;
-        }
-        h2 {
-            margin-top: 0;
-            color: #555;
-        }
-    </style>
-</head>
-<body>
-    <h1>Результаты интерполяции (N = %d узлов)</h1>
-    
-    <div class="charts-container">
+	// График ошибок добавляется только по запросу
+	errorChartContainer, errorChartScript := "", ""
+	if showErrorChart {
+		errorChartContainer = `        
+        <div class="chart-container full-width">
+            <h2>Сравнение абсолютных и относительных ошибок интерполяции</h2>
+            <canvas id="` + id + `errorChart"></canvas>
+        </div>`
+		errorChartScript = fmt.Sprintf(`
+        // График ошибок
+        const ctx4 = document.getElementById('%serrorChart').getContext('2d');
+        new Chart(ctx4, {
+            type: 'line',
+            data: {
+                labels: %s,
+                datasets: [{
+                    label: 'Ошибка Лагранжа (равномерные)',
+                    data: %s,
+                    borderColor: 'rgb(255, 99, 132)',
+                    borderWidth: 2,
+                    pointRadius: 0,
+                    tension: 0.1
+                }, {
+                    label: 'Ошибка Лагранжа (Чебышев)',
+                    data: %s,
+                    borderColor: 'rgb(153, 102, 255)',
+                    borderWidth: 2,
+                    pointRadius: 0,
+                    tension: 0.1
+                }, {
+                    label: 'Ошибка сплайна',
+                    data: %s,
+                    borderColor: 'rgb(54, 162, 235)',
+                    borderWidth: 2,
+                    pointRadius: 0,
+                    tension: 0.1
+                }, {
+                    label: 'Отн. ошибка Лагранжа (равномерные)',
+                    data: %s,
+                    borderColor: 'rgb(255, 99, 132)',
+                    borderWidth: 1,
+                    borderDash: [5, 5],
+                    pointRadius: 0,
+                    tension: 0.1
+                }, {
+                    label: 'Отн. ошибка Лагранжа (Чебышев)',
+                    data: %s,
+                    borderColor: 'rgb(153, 102, 255)',
+                    borderWidth: 1,
+                    borderDash: [5, 5],
+                    pointRadius: 0,
+                    tension: 0.1
+                }, {
+                    label: 'Отн. ошибка сплайна',
+                    data: %s,
+                    borderColor: 'rgb(54, 162, 235)',
+                    borderWidth: 1,
+                    borderDash: [5, 5],
+                    pointRadius: 0,
+                    tension: 0.1
+                }]
+            },
+            options: {
+                responsive: true,
+                maintainAspectRatio: false,
+                plugins: {
+                    legend: { position: 'top' }
+                },
+                scales: {
+                    x: { title: { display: true, text: 'x' } },
+                    y: { 
+                        type: 'logarithmic',
+                        title: { display: true, text: 'Ошибка (log)' } 
+                    }
+                }
+            }
+        });
+`, id, xValuesStr, lagrangeUniformErrorsStr, lagrangeChebyshevErrorsStr, splineErrorsStr,
+			lagrangeUniformRelErrorsStr, lagrangeChebyshevRelErrorsStr, splineRelErrorsStr)
+	}
+
+	markup = fmt.Sprintf(`    <div class="charts-container">
         <div class="chart-container full-width">
             <h2>Сравнение методов интерполяции</h2>
-            <canvas id="interpolationChart"></canvas>
+            <canvas id="%sinterpolationChart"></canvas>
         </div>
         
         <div class="chart-container">
             <h2>Равномерные узлы</h2>
-            <canvas id="uniformNodesChart"></canvas>
+            <canvas id="%suniformNodesChart"></canvas>
         </div>
         
         <div class="chart-container">
             <h2>Узлы Чебышева</h2>
-            <canvas id="chebyshevNodesChart"></canvas>
+            <canvas id="%schebyshevNodesChart"></canvas>
         </div>
         
         <div class="chart-container full-width">
-            <h2>Сравнение ошибок интерполяции</h2>
-            <canvas id="errorChart"></canvas>
+            <h2>Производная сплайна</h2>
+            <canvas id="%sderivativeChart"></canvas>
         </div>
+%s
     </div>
+`, id, id, id, id, errorChartContainer)
 
-    <script>
+	script = fmt.Sprintf(`
         // График интерполяции
-        const ctx1 = document.getElementById('interpolationChart').getContext('2d');
+        const ctx1 = document.getElementById('%sinterpolationChart').getContext('2d');
         new Chart(ctx1, {
             type: 'line',
             data: {
@@ -186,7 +497,7 @@ func generateHTML(uniformData, chebyshevData *interpolationData, testFunc func(f
         });
 
         // График равномерных узлов
-        const ctx2 = document.getElementById('uniformNodesChart').getContext('2d');
+        const ctx2 = document.getElementById('%suniformNodesChart').getContext('2d');
         new Chart(ctx2, {
             type: 'scatter',
             data: {
@@ -196,6 +507,13 @@ func generateHTML(uniformData, chebyshevData *interpolationData, testFunc func(f
                     borderColor: 'rgb(255, 99, 132)',
                     backgroundColor: 'rgba(255, 99, 132, 0.8)',
                     pointRadius: 6
+                }, {
+                    label: 'Кубический сплайн',
+                    data: %s.map((x, i) => ({x: x, y: %s[i]})),
+                    borderColor: 'rgb(54, 162, 235)',
+                    borderWidth: 2,
+                    pointRadius: 0,
+                    showLine: true
                 }]
             },
             options: {
@@ -212,7 +530,7 @@ func generateHTML(uniformData, chebyshevData *interpolationData, testFunc func(f
         });
 
         // График узлов Чебышева
-        const ctx3 = document.getElementById('chebyshevNodesChart').getContext('2d');
+        const ctx3 = document.getElementById('%schebyshevNodesChart').getContext('2d');
         new Chart(ctx3, {
             type: 'scatter',
             data: {
@@ -237,31 +555,25 @@ func generateHTML(uniformData, chebyshevData *interpolationData, testFunc func(f
             }
         });
 
-        // График ошибок
-        const ctx4 = document.getElementById('errorChart').getContext('2d');
-        new Chart(ctx4, {
+        // График производной сплайна
+        const ctx5 = document.getElementById('%sderivativeChart').getContext('2d');
+        new Chart(ctx5, {
             type: 'line',
             data: {
                 labels: %s,
                 datasets: [{
-                    label: 'Ошибка Лагранжа (равномерные)',
+                    label: 'Производная функции (центральная разность)',
                     data: %s,
-                    borderColor: 'rgb(255, 99, 132)',
-                    borderWidth: 2,
-                    pointRadius: 0,
-                    tension: 0.1
-                }, {
-                    label: 'Ошибка Лагранжа (Чебышев)',
-                    data: %s,
-                    borderColor: 'rgb(153, 102, 255)',
-                    borderWidth: 2,
+                    borderColor: 'rgb(75, 192, 192)',
+                    borderWidth: 3,
                     pointRadius: 0,
                     tension: 0.1
                 }, {
-                    label: 'Ошибка сплайна',
+                    label: 'Производная сплайна',
                     data: %s,
                     borderColor: 'rgb(54, 162, 235)',
                     borderWidth: 2,
+                    borderDash: [5, 5],
                     pointRadius: 0,
                     tension: 0.1
                 }]
@@ -274,23 +586,77 @@ func generateHTML(uniformData, chebyshevData *interpolationData, testFunc func(f
                 },
                 scales: {
                     x: { title: { display: true, text: 'x' } },
-                    y: { 
-                        type: 'logarithmic',
-                        title: { display: true, text: 'Ошибка (log)' } 
-                    }
+                    y: { title: { display: true, text: "f'(x)" } }
                 }
             }
         });
-    </script>
-</body>
-</html>`, uniformData.n, xValuesStr, originalValuesStr, lagrangeUniformValuesStr, lagrangeChebyshevValuesStr,
-		splineValuesStr, uniformNodesXStr, uniformNodesYStr, chebyshevNodesXStr, chebyshevNodesYStr,
-		xValuesStr, lagrangeUniformErrorsStr, lagrangeChebyshevErrorsStr, splineErrorsStr)
+%s`, id, xValuesStr, originalValuesStr, lagrangeUniformValuesStr, lagrangeChebyshevValuesStr, splineValuesStr,
+		id, uniformNodesXStr, uniformNodesYStr, xValuesStr, splineValuesStr,
+		id, chebyshevNodesXStr, chebyshevNodesYStr,
+		id, xValuesStr, trueDerivativesStr, splineDerivativesStr, errorChartScript)
 
-	return os.WriteFile(filename, []byte(htmlContent), 0644)
+	return markup, script, nil
+}
+
+// renderPage собирает HTML страницу из разметки body и скрипта графиков script
+func renderPage(body, script string) string {
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Результаты интерполяции</title>
+    <script src="https://cdnjs.cloudflare.com/ajax/libs/Chart.js/3.9.1/chart.min.js"></script>
+    <style>
+        body {
+            font-family: Arial, sans-serif;
+            max-width: 1600px;
+            margin: 0 auto;
+            padding: 20px;
+            background: #f5f5f5;
+        }
+        h1 {
+            text-align: center;
+            color: #333;
+        }
+        .charts-container {
+            display: grid;
+            grid-template-columns: 1fr 1fr;
+            gap: 20px;
+            margin-bottom: 20px;
+        }
+        .chart-container {
+            background: white;
+            padding: 20px;
+            border-radius: 8px;
+            box-shadow: 0 2px 4px rgba(0,0,0,0.1);
+        }
+        .full-width {
+            grid-column: 1 / -1;
+        }
+        canvas {
+            max-width: 100%%;
+            height: 400px !important;
+        }
+        h2 {
+            margin-top: 0;
+            color: #555;
+        }
+        h2.section-title {
+            margin-top: 30px;
+            color: #333;
+        }
+    </style>
+</head>
+<body>
+%s
+    <script>%s    </script>
+</body>
+</html>`, body, script)
 }
 
-// floatSliceToJS конвертирует срез float64 в JavaScript массив
+// floatSliceToJS конвертирует срез float64 в JavaScript массив. Числа выводятся с полной
+// точностью, а NaN и бесконечности заменяются на null, чтобы Chart.js пропускал такие точки
 func floatSliceToJS(values []float64) string {
 	var result strings.Builder
 	result.WriteString("[")
@@ -298,7 +664,11 @@ func floatSliceToJS(values []float64) string {
 		if i > 0 {
 			result.WriteString(",")
 		}
-		result.WriteString(fmt.Sprintf("%.6f", v))
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			result.WriteString("null")
+			continue
+		}
+		result.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
 	}
 	result.WriteString("]")
 	return result.String()
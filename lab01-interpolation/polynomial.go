@@ -0,0 +1,47 @@
+package main
+
+import "errors"
+
+// hornerEval вычисляет значение полинома c0 + c1*x + ... + cn*x^n по схеме Горнера.
+// В отличие от суммирования степеней x^k схема выполняет n умножений без возведения
+// в степень и накапливает меньшую ошибку округления при больших x
+func hornerEval(coeffs []float64, x float64) float64 {
+	result := 0.0
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		result = result*x + coeffs[i]
+	}
+	return result
+}
+
+// hornerDeriv вычисляет производную полинома с коэффициентами coeffs (по возрастанию степеней)
+// в точке x, одновременно с значением полинома продвигаясь по схеме Горнера
+func hornerDeriv(coeffs []float64, x float64) float64 {
+	value, derivative := 0.0, 0.0
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		derivative = derivative*x + value
+		value = value*x + coeffs[i]
+	}
+	return derivative
+}
+
+// vandermondeCoefficients находит коэффициенты интерполяционного полинома степени n по n+1
+// точкам (по возрастанию степеней), решая систему с матрицей Вандермонда через LU-разложение
+// с выбором ведущего элемента. Матрица Вандермонда плохо обусловлена: ее число обусловленности
+// растет экспоненциально со степенью, поэтому при степени больше 10-15 коэффициенты теряют
+// точность. Для вычисления значений полинома лучше использовать lagrangeInterpolation
+func vandermondeCoefficients(points []point) ([]float64, error) {
+	if len(points) == 0 {
+		return nil, errors.New("не задано ни одной точки")
+	}
+
+	lu, perm, err := luDecompose(vandermondeMatrix(points, len(points)-1))
+	if err != nil {
+		return nil, err
+	}
+
+	y := make([]float64, len(points))
+	for i, p := range points {
+		y[i] = p.y
+	}
+	return luSolve(lu, perm, y), nil
+}
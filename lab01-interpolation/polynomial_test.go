@@ -0,0 +1,88 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// binomialCoefficients возвращает коэффициенты (x + shift)^n по возрастанию степеней
+func binomialCoefficients(n int, shift float64) []float64 {
+	coeffs := make([]float64, n+1)
+	c := 1.0
+	for k := 0; k <= n; k++ {
+		coeffs[k] = c * math.Pow(shift, float64(n-k))
+		c = c * float64(n-k) / float64(k+1)
+	}
+	return coeffs
+}
+
+// naiveEval вычисляет полином суммированием c(k) * x^k
+func naiveEval(coeffs []float64, x float64) float64 {
+	sum := 0.0
+	for k, c := range coeffs {
+		sum += c * math.Pow(x, float64(k))
+	}
+	return sum
+}
+
+func TestHornerMatchesNaiveSum(t *testing.T) {
+	const n = 15
+	coeffs := binomialCoefficients(n, 1)
+	for _, x := range []float64{-0.5, 0.3, 1.5, 2} {
+		want := math.Pow(x+1, n)
+		horner, naive := hornerEval(coeffs, x), naiveEval(coeffs, x)
+		if math.Abs(horner-want) > 1e-13*math.Abs(want) || math.Abs(naive-want) > 1e-13*math.Abs(want) {
+			t.Errorf("x = %g: Горнер %.17g, сумма степеней %.17g, точно %.17g", x, horner, naive, want)
+		}
+		if d, want := hornerDeriv(coeffs, x), n*math.Pow(x+1, n-1); math.Abs(d-want) > 1e-13*math.Abs(want) {
+			t.Errorf("x = %g: производная по Горнеру %.17g, точно %.17g", x, d, want)
+		}
+	}
+}
+
+func TestHornerErrorBound(t *testing.T) {
+	// Схема Горнера обратно устойчива: ее ошибка не больше 2n*eps * sum|c(k)|*|x|^k. Вблизи
+	// кратного корня (x - 2)^10 значение мало, а слагаемые велики, и граница достигается
+	const n = 10
+	coeffs := binomialCoefficients(n, -2)
+	eps := math.Nextafter(1, 2) - 1
+	for _, x := range []float64{1.9, 1.99, 2.01, 2.1, 3} {
+		bound := 2 * n * eps * hornerEval(binomialCoefficients(n, 2), math.Abs(x))
+		if err := math.Abs(hornerEval(coeffs, x) - math.Pow(x-2, n)); err > bound {
+			t.Errorf("x = %g: ошибка схемы Горнера %.3e больше оценки %.3e", x, err, bound)
+		}
+	}
+}
+
+func TestVandermondeCoefficientsCubic(t *testing.T) {
+	// testCubic(x) = 2 - x + 0.5x^2 + 0.3x^3
+	want := []float64{2, -1, 0.5, 0.3}
+	data, err := sampleAt([]float64{-1, 0.5, 2, 3}, testCubic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coeffs, err := vandermondeCoefficients(data.points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(coeffs) != len(want) {
+		t.Fatalf("%d коэффициентов, ожидалось %d", len(coeffs), len(want))
+	}
+	for k := range want {
+		if math.Abs(coeffs[k]-want[k]) > 1e-6 {
+			t.Errorf("c[%d] = %.10g, ожидалось %g", k, coeffs[k], want[k])
+		}
+	}
+	for _, x := range []float64{-0.7, 1, 2.5} {
+		if got := hornerEval(coeffs, x); math.Abs(got-testCubic(x)) > 1e-12 {
+			t.Errorf("P(%g) = %.15g, ожидалось %.15g", x, got, testCubic(x))
+		}
+	}
+
+	if _, err := vandermondeCoefficients(nil); err == nil {
+		t.Error("ожидалась ошибка для пустого набора точек")
+	}
+	if _, err := vandermondeCoefficients([]point{{1, 0}, {1, 2}}); err == nil {
+		t.Error("ожидалась ошибка для совпадающих узлов")
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// trapezoidRule вычисляет интеграл f на [a, b] составной формулой трапеций с n отрезками
+func trapezoidRule(f func(float64) float64, a, b float64, n int) float64 {
+	h := (b - a) / float64(n)
+	sum := (f(a) + f(b)) / 2
+	for i := 1; i < n; i++ {
+		sum += f(a + float64(i)*h)
+	}
+	return sum * h
+}
+
+// simpsonRule вычисляет интеграл f на [a, b] составной формулой Симпсона с n отрезками.
+// Количество отрезков n должно быть четным
+func simpsonRule(f func(float64) float64, a, b float64, n int) (float64, error) {
+	if n < 2 || n%2 != 0 {
+		return 0, fmt.Errorf("формула Симпсона требует четное количество отрезков, получено %d", n)
+	}
+
+	h := (b - a) / float64(n)
+	sum := f(a) + f(b)
+	for i := 1; i < n; i++ {
+		x := a + float64(i)*h
+		if i%2 == 1 {
+			sum += 4 * f(x)
+		} else {
+			sum += 2 * f(x)
+		}
+	}
+	return sum * h / 3, nil
+}
+
+// rombergTol - допуск сходимости метода Ромберга: вычисления прекращаются, когда два
+// последовательных диагональных элемента таблицы отличаются меньше чем на rombergTol
+const rombergTol = 1e-12
+
+// romberg вычисляет интеграл f на [a, b] методом Ромберга: формулы трапеций с шагом,
+// уменьшаемым вдвое, уточняются экстраполяцией Ричардсона. Строится не более maxLevels строк
+// таблицы; новая строка использует значения предыдущей трапеции и вычисляет f только в новых точках
+func romberg(f func(float64) float64, a, b float64, maxLevels int) float64 {
+	h := b - a
+	prev := []float64{h * (f(a) + f(b)) / 2}
+
+	for level := 1; level < maxLevels; level++ {
+		// Трапеция с вдвое меньшим шагом: добавляем середины отрезков предыдущего разбиения
+		segments := 1 << (level - 1)
+		sum := 0.0
+		for i := 0; i < segments; i++ {
+			sum += f(a + (float64(i)+0.5)*h)
+		}
+		h /= 2
+
+		cur := make([]float64, level+1)
+		cur[0] = prev[0]/2 + h*sum
+
+		// Экстраполяция Ричардсона: R(k, j) = R(k, j-1) + (R(k, j-1) - R(k-1, j-1)) / (4^j - 1)
+		factor := 1.0
+		for j := 1; j <= level; j++ {
+			factor *= 4
+			cur[j] = cur[j-1] + (cur[j-1]-prev[j-1])/(factor-1)
+		}
+
+		if math.Abs(cur[level]-prev[level-1]) < rombergTol {
+			return cur[level]
+		}
+		prev = cur
+	}
+
+	return prev[len(prev)-1]
+}
+
+// adaptiveSimpsonMaxDepth ограничивает глубину рекурсии адаптивного метода Симпсона
+const adaptiveSimpsonMaxDepth = 50
+
+// adaptiveSimpson вычисляет интеграл f на [a, b] адаптивным методом Симпсона: отрезок делится
+// пополам, пока локальная оценка погрешности не станет меньше допуска. Вторым значением
+// возвращается количество вычислений функции
+func adaptiveSimpson(f func(float64) float64, a, b, tol float64) (float64, int) {
+	fa, fb := f(a), f(b)
+	m := (a + b) / 2
+	fm := f(m)
+	whole := (b - a) / 6 * (fa + 4*fm + fb)
+
+	evaluations := 3
+	result := adaptiveSimpsonStep(f, a, b, fa, fm, fb, whole, tol, adaptiveSimpsonMaxDepth, &evaluations)
+	return result, evaluations
+}
+
+// adaptiveSimpsonStep рекурсивно уточняет интеграл на [a, b] по уже известным значениям
+// в концах и середине отрезка; whole - формула Симпсона для всего отрезка
+func adaptiveSimpsonStep(f func(float64) float64, a, b, fa, fm, fb, whole, tol float64, depth int, evaluations *int) float64 {
+	m := (a + b) / 2
+	lm := (a + m) / 2
+	rm := (m + b) / 2
+	flm, frm := f(lm), f(rm)
+	*evaluations += 2
+
+	left := (m - a) / 6 * (fa + 4*flm + fm)
+	right := (b - m) / 6 * (fm + 4*frm + fb)
+	delta := left + right - whole
+
+	// Оценка погрешности по правилу Рунге: ошибка половинок примерно в 15 раз меньше delta
+	if depth <= 0 || math.Abs(delta) <= 15*tol {
+		return left + right + delta/15
+	}
+
+	return adaptiveSimpsonStep(f, a, m, fa, flm, fm, left, tol/2, depth-1, evaluations) +
+		adaptiveSimpsonStep(f, m, b, fm, frm, fb, right, tol/2, depth-1, evaluations)
+}
+
+// gaussLegendre вычисляет узлы и веса n-точечной квадратуры Гаусса-Лежандра на [-1, 1].
+// Узлы - корни полинома Лежандра P(n), уточняемые методом Ньютона; узлы упорядочены по возрастанию
+func gaussLegendre(n int) (nodes, weights []float64) {
+	nodes = make([]float64, n)
+	weights = make([]float64, n)
+
+	// Корни симметричны относительно нуля, поэтому ищем только половину
+	for i := 0; i < (n+1)/2; i++ {
+		// Начальное приближение - асимптотическая оценка i-го корня
+		x := math.Cos(math.Pi * (float64(i) + 0.75) / (float64(n) + 0.5))
+
+		var derivative float64
+		for iter := 0; iter < 100; iter++ {
+			var p float64
+			p, derivative = legendre(n, x)
+			dx := p / derivative
+			x -= dx
+			if math.Abs(dx) < 1e-15 {
+				break
+			}
+		}
+		_, derivative = legendre(n, x)
+
+		w := 2 / ((1 - x*x) * derivative * derivative)
+		nodes[i], nodes[n-1-i] = -x, x
+		weights[i], weights[n-1-i] = w, w
+	}
+
+	return nodes, weights
+}
+
+// legendre вычисляет полином Лежандра P(n) и его производную в точке x по рекуррентной формуле
+func legendre(n int, x float64) (p, derivative float64) {
+	if n == 0 {
+		return 1, 0
+	}
+	p0, p1 := 1.0, x
+	for k := 2; k <= n; k++ {
+		p0, p1 = p1, ((2*float64(k)-1)*x*p1-(float64(k)-1)*p0)/float64(k)
+	}
+	derivative = float64(n) * (x*p1 - p0) / (x*x - 1)
+	return p1, derivative
+}
+
+// gaussLegendreIntegrate вычисляет интеграл f на [a, b] n-точечной квадратурой Гаусса-Лежандра,
+// отображая узлы с [-1, 1] на [a, b]
+func gaussLegendreIntegrate(f func(float64) float64, a, b float64, n int) float64 {
+	nodes, weights := gaussLegendre(n)
+	mid := (a + b) / 2
+	half := (b - a) / 2
+
+	sum := 0.0
+	for i := range nodes {
+		sum += weights[i] * f(mid+half*nodes[i])
+	}
+	return sum * half
+}
+
+// compareQuadratures сравнивает интеграл функции по квадратурным формулам
+// с точным интегралом кубического сплайна, построенного по узлам data
+func compareQuadratures(data *interpolationData, testFunc func(float64) float64) {
+	const n = 100
+
+	trapezoid := trapezoidRule(testFunc, data.a, data.b, n)
+	simpson, err := simpsonRule(testFunc, data.a, data.b, n)
+	if err != nil {
+		fmt.Printf("Ошибка при вычислении интеграла: %v\n", err)
+		return
+	}
+	adaptive, evaluations := adaptiveSimpson(testFunc, data.a, data.b, 1e-10)
+	gauss := gaussLegendreIntegrate(testFunc, data.a, data.b, 5)
+	rombergValue := romberg(testFunc, data.a, data.b, 20)
+	spline, err := newCubicSpline(data)
+	if err != nil {
+		fmt.Printf("Ошибка при построении сплайна: %v\n", err)
+		return
+	}
+	splineIntegral := spline.integrate(data.a, data.b)
+
+	fmt.Printf("Интеграл на [%g, %g]:\n", data.a, data.b)
+	fmt.Printf("  Формула трапеций (n = %d):   %.10f\n", n, trapezoid)
+	fmt.Printf("  Формула Симпсона (n = %d):   %.10f\n", n, simpson)
+	fmt.Printf("  Адаптивный Симпсон:           %.10f (%d вычислений функции)\n", adaptive, evaluations)
+	fmt.Printf("  Гаусс-Лежандр (5 узлов):      %.10f\n", gauss)
+	fmt.Printf("  Метод Ромберга:               %.10f\n", rombergValue)
+	fmt.Printf("  Интеграл сплайна:             %.10f (отличие от Симпсона %.3e)\n",
+		splineIntegral, math.Abs(splineIntegral-simpson))
+	fmt.Println()
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSimpsonExactForCubic(t *testing.T) {
+	// Первообразная testCubic
+	antiderivative := func(x float64) float64 {
+		return 2*x - x*x/2 + x*x*x/6 + 0.075*x*x*x*x
+	}
+	want := antiderivative(3) - antiderivative(-1)
+	for _, n := range []int{2, 4, 10} {
+		got, err := simpsonRule(testCubic, -1, 3, n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(got-want) > 1e-12 {
+			t.Errorf("n = %d: формула Симпсона дает %.15g, ожидалось %.15g", n, got, want)
+		}
+	}
+
+	for _, n := range []int{0, 1, 3, 7} {
+		if _, err := simpsonRule(testCubic, -1, 3, n); err == nil {
+			t.Errorf("n = %d: ожидалась ошибка для нечетного количества отрезков", n)
+		}
+	}
+}
+
+func TestTrapezoidSecondOrder(t *testing.T) {
+	want := math.E - 1
+	prevErr := math.Abs(trapezoidRule(math.Exp, 0, 1, 8) - want)
+	for _, n := range []int{16, 32, 64} {
+		err := math.Abs(trapezoidRule(math.Exp, 0, 1, n) - want)
+		// Ошибка порядка 1/n^2: при удвоении n она уменьшается в 4 раза
+		if ratio := prevErr / err; math.Abs(ratio-4) > 0.05 {
+			t.Errorf("n = %d: ошибка уменьшилась в %.3f раза, ожидалось 4", n, ratio)
+		}
+		prevErr = err
+	}
+}
+
+func TestAdaptiveSimpsonKink(t *testing.T) {
+	const tol = 1e-8
+	for _, tc := range []struct {
+		a, b, want float64
+	}{
+		{-1, 1, 1},
+		// Излом не совпадает с серединой отрезка
+		{-0.3, 1, 0.545},
+	} {
+		got, evaluations := adaptiveSimpson(moduleFunction, tc.a, tc.b, tol)
+		if math.Abs(got-tc.want) > tol {
+			t.Errorf("интеграл |x| по [%g, %g] = %.12g, ожидалось %g", tc.a, tc.b, got, tc.want)
+		}
+		if evaluations < 3 {
+			t.Errorf("[%g, %g]: %d вычислений функции", tc.a, tc.b, evaluations)
+		}
+	}
+}
+
+func TestAdaptiveSimpsonDepthLimit(t *testing.T) {
+	// Разрывная функция не дает оценке погрешности опуститься ниже tol: рекурсия
+	// должна остановиться на adaptiveSimpsonMaxDepth
+	step := func(x float64) float64 {
+		if x < 1/math.Pi {
+			return 0
+		}
+		return 1
+	}
+	got, _ := adaptiveSimpson(step, 0, 1, 1e-300)
+	if math.Abs(got-(1-1/math.Pi)) > 1e-10 {
+		t.Errorf("интеграл ступеньки %.12g, ожидалось %.12g", got, 1-1/math.Pi)
+	}
+}
+
+func TestGaussLegendreExactForPolynomials(t *testing.T) {
+	for _, n := range []int{2, 3, 5} {
+		nodes, weights := gaussLegendre(n)
+		if len(nodes) != n || len(weights) != n {
+			t.Fatalf("n = %d: %d узлов и %d весов", n, len(nodes), len(weights))
+		}
+
+		// Интеграл x^k по [-1, 1]: 2/(k+1) для четных k, 0 для нечетных
+		for k := 0; k <= 2*n-1; k++ {
+			want := 0.0
+			if k%2 == 0 {
+				want = 2 / float64(k+1)
+			}
+			got := 0.0
+			for i, x := range nodes {
+				got += weights[i] * math.Pow(x, float64(k))
+			}
+			if math.Abs(got-want) > 1e-10 {
+				t.Errorf("n = %d: интеграл x^%d = %.15g, ожидалось %.15g", n, k, got, want)
+			}
+		}
+	}
+}
+
+func TestGaussLegendreIntegrate(t *testing.T) {
+	// Правило по 3 узлам точно для многочленов степени 5 на любом отрезке
+	antiderivative := func(x float64) float64 {
+		return 2*x - x*x/2 + x*x*x/6 + 0.075*x*x*x*x
+	}
+	if got, want := gaussLegendreIntegrate(testCubic, -1, 3, 3), antiderivative(3)-antiderivative(-1); math.Abs(got-want) > 1e-10 {
+		t.Errorf("интеграл кубического полинома %.15g, ожидалось %.15g", got, want)
+	}
+	if got, want := gaussLegendreIntegrate(math.Exp, 0, 1, 8), math.E-1; math.Abs(got-want) > 1e-14 {
+		t.Errorf("интеграл exp по [0, 1] %.15g, ожидалось %.15g", got, want)
+	}
+}
+
+// testFunctionIntegral - точный интеграл тестовой функции по [1, 5]:
+// первообразная x*ln(x+1) равна (x^2 - 1)/2 * ln(x+1) - x^2/4 + x/2
+var testFunctionIntegral = (12*math.Log(6)-4)/math.Ln10 - 4
+
+func TestRombergBeatsSimpson(t *testing.T) {
+	evaluations := 0
+	counted := func(x float64) float64 {
+		evaluations++
+		return testFunction(x)
+	}
+
+	for _, levels := range []int{3, 4, 5} {
+		evaluations = 0
+		rombergErr := math.Abs(romberg(counted, 1, 5, levels) - testFunctionIntegral)
+		if want := 1<<(levels-1) + 1; evaluations != want {
+			t.Errorf("%d строк: %d вычислений функции, ожидалось %d", levels, evaluations, want)
+		}
+
+		// Формула Симпсона по тем же точкам
+		simpson, err := simpsonRule(testFunction, 1, 5, evaluations-1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		simpsonErr := math.Abs(simpson - testFunctionIntegral)
+		if !(rombergErr < simpsonErr) {
+			t.Errorf("%d вычислений: ошибка метода Ромберга %.3e не меньше ошибки Симпсона %.3e",
+				evaluations, rombergErr, simpsonErr)
+		}
+	}
+
+	if got := romberg(testFunction, 1, 5, 20); math.Abs(got-testFunctionIntegral) > 1e-11 {
+		t.Errorf("метод Ромберга дает %.15g, ожидалось %.15g", got, testFunctionIntegral)
+	}
+}
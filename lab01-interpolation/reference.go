@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+)
+
+// referenceTolerance - допустимое отклонение сплайна от эталонных значений
+const referenceTolerance = 1e-6
+
+// writeReferenceCSV записывает в CSV файл пары (x, значение сплайна) в точках xs.
+// Используется для генерации эталона, с которым затем сверяется сплайн
+func writeReferenceCSV(filename string, spline evaluator, xs []float64) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write([]string{"x", "expected"}); err != nil {
+		return err
+	}
+	for _, x := range xs {
+		if err := writer.Write([]string{formatCSVFloat(x), formatCSVFloat(spline.evaluate(x))}); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// loadReferenceCSV читает из CSV файла пары (x, ожидаемое значение), например полученные
+// сторонним инструментом (scipy.interpolate.CubicSpline с bc_type="natural").
+// Первая строка пропускается, если она не числовая (заголовок)
+func loadReferenceCSV(filename string) ([]point, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = 2
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("чтение %s: %w", filename, err)
+	}
+
+	points := make([]point, 0, len(records))
+	for i, record := range records {
+		x, errX := strconv.ParseFloat(record[0], 64)
+		y, errY := strconv.ParseFloat(record[1], 64)
+		if errX != nil || errY != nil {
+			if i == 0 {
+				continue
+			}
+			return nil, fmt.Errorf("%s, строка %d: некорректные числа %q", filename, i+1, record)
+		}
+		points = append(points, point{x: x, y: y})
+	}
+
+	return points, nil
+}
+
+// checkReference сверяет значения сплайна с эталонными точками и возвращает ошибку
+// с описанием наибольшего отклонения, если оно превышает tol
+func checkReference(spline evaluator, reference []point, tol float64) error {
+	worst, worstIdx := 0.0, -1
+	failed := 0
+	for i, p := range reference {
+		diff := math.Abs(spline.evaluate(p.x) - p.y)
+		if diff > tol || math.IsNaN(diff) {
+			failed++
+		}
+		if diff > worst || math.IsNaN(diff) && worstIdx < 0 {
+			worst, worstIdx = diff, i
+		}
+	}
+
+	if failed > 0 {
+		p := reference[worstIdx]
+		return fmt.Errorf("сплайн расходится с эталоном в %d из %d точек; наибольшее отклонение %.3e при x = %g (ожидалось %g, получено %g)",
+			failed, len(reference), worst, p.x, p.y, spline.evaluate(p.x))
+	}
+	return nil
+}
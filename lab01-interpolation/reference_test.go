@@ -0,0 +1,69 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// Эталон testdata/natural_spline_reference.csv построен скриптом natural_spline_reference.py
+// в точной рациональной арифметике, независимо от Go-реализации сплайна
+func TestNaturalSplineReference(t *testing.T) {
+	nodes, err := loadReferenceCSV(filepath.Join("testdata", "natural_spline_nodes.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reference, err := loadReferenceCSV(filepath.Join("testdata", "natural_spline_reference.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 11 || len(reference) != 101 {
+		t.Fatalf("%d узлов и %d эталонных точек, ожидалось 11 и 101", len(nodes), len(reference))
+	}
+
+	spline, err := newCubicSpline(&interpolationData{
+		points: nodes,
+		a:      nodes[0].x,
+		b:      nodes[len(nodes)-1].x,
+		n:      len(nodes) - 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checkReference(spline, reference, referenceTolerance); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReferenceCSVRoundTrip(t *testing.T) {
+	data, err := createGrid(1, 5, 6, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spline, err := newCubicSpline(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filename := filepath.Join(t.TempDir(), "reference.csv")
+	if err := writeReferenceCSV(filename, spline, linspace(1, 5, 41)); err != nil {
+		t.Fatal(err)
+	}
+	reference, err := loadReferenceCSV(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reference) != 41 {
+		t.Fatalf("%d точек, ожидалась 41", len(reference))
+	}
+	if err := checkReference(spline, reference, 0); err != nil {
+		t.Error(err)
+	}
+
+	// Эталон другого сплайна должен не пройти проверку
+	other, err := newNotAKnotSpline(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checkReference(other, reference, referenceTolerance); err == nil {
+		t.Error("ожидалось расхождение not-a-knot сплайна с эталоном натурального")
+	}
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// bisectionMaxIter ограничивает число делений отрезка пополам, если tol меньше
+// достижимой в float64 точности
+const bisectionMaxIter = 200
+
+// bisection находит корень f на [a, b] методом деления отрезка пополам с точностью tol по x.
+// Значения f(a) и f(b) должны иметь разные знаки
+func bisection(f func(float64) float64, a, b, tol float64) (float64, error) {
+	fa, fb := f(a), f(b)
+	if fa == 0 {
+		return a, nil
+	}
+	if fb == 0 {
+		return b, nil
+	}
+	if math.Signbit(fa) == math.Signbit(fb) {
+		return 0, fmt.Errorf("f(a) = %g и f(b) = %g одного знака: корень на [%g, %g] не локализован", fa, fb, a, b)
+	}
+
+	for iter := 0; iter < bisectionMaxIter && (b-a)/2 > tol; iter++ {
+		m := a + (b-a)/2
+		fm := f(m)
+		if fm == 0 {
+			return m, nil
+		}
+		if math.Signbit(fm) == math.Signbit(fa) {
+			a, fa = m, fm
+		} else {
+			b = m
+		}
+	}
+
+	return a + (b-a)/2, nil
+}
+
+// newtonRaphson находит корень f методом Ньютона x(k+1) = x(k) - f(x(k))/f'(x(k)), начиная с x0.
+// Итерации прекращаются, когда шаг становится меньше tol. Метод сходится квадратично
+// вблизи простого корня, но может разойтись при плохом начальном приближении
+func newtonRaphson(f, df func(float64) float64, x0, tol float64, maxIter int) (float64, error) {
+	x := x0
+	for iter := 0; iter < maxIter; iter++ {
+		d := df(x)
+		if d == 0 {
+			return x, fmt.Errorf("метод Ньютона: нулевая производная в точке x = %g", x)
+		}
+
+		step := f(x) / d
+		x -= step
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return x, fmt.Errorf("метод Ньютона разошелся на итерации %d", iter+1)
+		}
+		if math.Abs(step) < tol {
+			return x, nil
+		}
+	}
+
+	return x, fmt.Errorf("метод Ньютона не сошелся за %d итераций", maxIter)
+}
+
+// secant находит корень f методом секущих по двум начальным приближениям x0, x1:
+// производная метода Ньютона заменяется наклоном секущей. Итерации прекращаются,
+// когда шаг становится меньше tol
+func secant(f func(float64) float64, x0, x1, tol float64, maxIter int) (float64, error) {
+	f0, f1 := f(x0), f(x1)
+	for iter := 0; iter < maxIter; iter++ {
+		if f1 == f0 {
+			if f1 == 0 {
+				return x1, nil
+			}
+			return x1, fmt.Errorf("метод секущих: f(x0) = f(x1) = %g, секущая горизонтальна", f1)
+		}
+
+		step := f1 * (x1 - x0) / (f1 - f0)
+		x0, f0 = x1, f1
+		x1 -= step
+		if math.IsNaN(x1) || math.IsInf(x1, 0) {
+			return x1, fmt.Errorf("метод секущих разошелся на итерации %d", iter+1)
+		}
+		if math.Abs(step) < tol {
+			return x1, nil
+		}
+		f1 = f(x1)
+	}
+
+	return x1, fmt.Errorf("метод секущих не сошелся за %d итераций", maxIter)
+}
+
+// steffensenEps - порог относительной малости знаменателя в методе Стеффенсена
+const steffensenEps = 1e-14
+
+// steffensen находит корень f методом Стеффенсена x(k+1) = x(k) - f(x)^2 / (f(x + f(x)) - f(x)),
+// начиная с x0: производная метода Ньютона заменяется разностным отношением с шагом f(x).
+// Сходится квадратично вблизи простого корня, используя только значения функции, но, как и
+// метод Ньютона, требует хорошего начального приближения. Итерации прекращаются, когда шаг
+// становится меньше tol
+func steffensen(f func(float64) float64, x0, tol float64, maxIter int) (float64, error) {
+	x := x0
+	for iter := 0; iter < maxIter; iter++ {
+		fx := f(x)
+		if fx == 0 {
+			return x, nil
+		}
+
+		// Знаменатель примерно равен f'(x) * f(x); относительно f(x) малый знаменатель
+		// означает почти нулевую производную
+		denom := f(x+fx) - fx
+		if math.Abs(denom) <= steffensenEps*math.Abs(fx) {
+			return x, fmt.Errorf("метод Стеффенсена: вырожденный знаменатель %g в точке x = %g", denom, x)
+		}
+
+		step := fx * fx / denom
+		x -= step
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return x, fmt.Errorf("метод Стеффенсена разошелся на итерации %d", iter+1)
+		}
+		if math.Abs(step) < tol {
+			return x, nil
+		}
+	}
+
+	return x, fmt.Errorf("метод Стеффенсена не сошелся за %d итераций", maxIter)
+}
+
+// brentMaxIter ограничивает число итераций метода Брента
+const brentMaxIter = 200
+
+// brent находит корень f на [a, b] методом Брента: на каждом шаге пробуется обратная
+// квадратичная интерполяция или секущая, а если шаг выходит за отрезок локализации или
+// сходимость замедляется - деление пополам. Сходится всегда, как бисекция, и обычно
+// сверхлинейно, не требуя производной. Значения f(a) и f(b) должны иметь разные знаки
+func brent(f func(float64) float64, a, b, tol float64) (float64, error) {
+	fa, fb := f(a), f(b)
+	if fa == 0 {
+		return a, nil
+	}
+	if fb == 0 {
+		return b, nil
+	}
+	if math.Signbit(fa) == math.Signbit(fb) {
+		return 0, fmt.Errorf("f(a) = %g и f(b) = %g одного знака: корень на [%g, %g] не локализован", fa, fb, a, b)
+	}
+
+	// b - текущее приближение, a - предыдущее, c - противоположный конец отрезка локализации
+	c, fc := a, fa
+	d := b - a
+	e := d
+	for iter := 0; iter < brentMaxIter; iter++ {
+		if math.Signbit(fb) == math.Signbit(fc) {
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+
+		// Допуск с учетом машинной точности в окрестности b
+		tol1 := 2*1e-16*math.Abs(b) + tol/2
+		xm := (c - b) / 2
+		if math.Abs(xm) <= tol1 || fb == 0 {
+			return b, nil
+		}
+
+		if math.Abs(e) >= tol1 && math.Abs(fa) > math.Abs(fb) {
+			// Пробуем интерполяцию: секущую при a == c, иначе обратную квадратичную
+			s := fb / fa
+			var p, q float64
+			if a == c {
+				p = 2 * xm * s
+				q = 1 - s
+			} else {
+				q = fa / fc
+				r := fb / fc
+				p = s * (2*xm*q*(q-r) - (b-a)*(r-1))
+				q = (q - 1) * (r - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			}
+			p = math.Abs(p)
+
+			// Принимаем интерполяцию, только если шаг остается внутри отрезка и уменьшается
+			if 2*p < math.Min(3*xm*q-math.Abs(tol1*q), math.Abs(e*q)) {
+				e = d
+				d = p / q
+			} else {
+				d = xm
+				e = d
+			}
+		} else {
+			d = xm
+			e = d
+		}
+
+		a, fa = b, fb
+		if math.Abs(d) > tol1 {
+			b += d
+		} else {
+			b += math.Copysign(tol1, xm)
+		}
+		fb = f(b)
+	}
+
+	return b, fmt.Errorf("метод Брента не сошелся за %d итераций", brentMaxIter)
+}
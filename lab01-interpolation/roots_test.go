@@ -0,0 +1,126 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// testFunctionRoot - корень уравнения x*log10(x+1) = 1, вычисленный с 40 знаками
+const testFunctionRoot = 2.059246626620982723373235767804069514440
+
+func TestBisection(t *testing.T) {
+	root, err := bisection(testFunction, 1, 5, 1e-12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(root-testFunctionRoot) > 1e-12 {
+		t.Errorf("bisection = %.15f, ожидалось %.15f", root, testFunctionRoot)
+	}
+
+	if _, err := bisection(testFunction, 3, 5, 1e-12); err == nil {
+		t.Error("ожидалась ошибка для f(a) и f(b) одного знака")
+	}
+	if root, err := bisection(func(x float64) float64 { return x - 1 }, 1, 5, 1e-12); err != nil || root != 1 {
+		t.Errorf("корень на левом конце: %g, %v", root, err)
+	}
+}
+
+func TestNewtonRaphson(t *testing.T) {
+	root, err := newtonRaphson(testFunction, testFunctionDerivative, 1, 1e-14, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(root-testFunctionRoot) > 1e-14 {
+		t.Errorf("newtonRaphson = %.15f, ожидалось %.15f", root, testFunctionRoot)
+	}
+
+	square := func(x float64) float64 { return x*x + 1 }
+	if _, err := newtonRaphson(square, func(x float64) float64 { return 2 * x }, 0, 1e-12, 50); err == nil {
+		t.Error("ожидалась ошибка при нулевой производной")
+	}
+	if _, err := newtonRaphson(square, func(x float64) float64 { return 2 * x }, 1, 1e-12, 50); err == nil {
+		t.Error("ожидалась ошибка для функции без вещественных корней")
+	}
+}
+
+func TestSecant(t *testing.T) {
+	root, err := secant(testFunction, 1, 5, 1e-14, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(root-testFunctionRoot) > 1e-14 {
+		t.Errorf("secant = %.15f, ожидалось %.15f", root, testFunctionRoot)
+	}
+
+	if _, err := secant(func(float64) float64 { return 1 }, 0, 1, 1e-12, 50); err == nil {
+		t.Error("ожидалась ошибка для горизонтальной секущей")
+	}
+}
+
+func TestBrent(t *testing.T) {
+	root, err := brent(testFunction, 1, 5, 1e-14)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(root-testFunctionRoot) > 1e-14 {
+		t.Errorf("brent = %.15f, ожидалось %.15f", root, testFunctionRoot)
+	}
+	if _, err := brent(testFunction, 3, 5, 1e-12); err == nil {
+		t.Error("ожидалась ошибка для f(a) и f(b) одного знака")
+	}
+
+	// Метод Ньютона для arctg расходится при |x0| > 1.39: каждый шаг перескакивает корень
+	// все дальше. Метод Брента на отрезке локализации все равно сходится
+	dAtan := func(x float64) float64 { return 1 / (1 + x*x) }
+	if x, err := newtonRaphson(math.Atan, dAtan, 2, 1e-12, 50); err == nil && math.Abs(x) < 1e-6 {
+		t.Fatalf("метод Ньютона из x0 = 2 неожиданно сошелся к %g", x)
+	}
+	root, err = brent(math.Atan, -2, 3, 1e-12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(root) > 1e-12 {
+		t.Errorf("brent(arctg) = %g, ожидался 0", root)
+	}
+}
+
+func TestSteffensen(t *testing.T) {
+	// Итерации сравниваются по количеству вычислений функции: у Стеффенсена их два на шаг
+	calls := 0
+	counted := func(x float64) float64 {
+		calls++
+		return testFunction(x)
+	}
+
+	// При квадратичной сходимости шаг около 1e-8 уже дает погрешность порядка 1e-16.
+	// Требовать шаг меньше 1e-13 нельзя: в корне f(x) меньше шага чисел около x,
+	// и знаменатель f(x + f(x)) - f(x) обращается в ноль
+	root, err := steffensen(counted, 2.5, 1e-7, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(root-testFunctionRoot) > 1e-13 {
+		t.Errorf("steffensen = %.15f, ожидалось %.15f", root, testFunctionRoot)
+	}
+	steffensenCalls := calls
+
+	calls = 0
+	if _, err := bisection(counted, 1, 5, 1e-13); err != nil {
+		t.Fatal(err)
+	}
+	if !(4*steffensenCalls < calls) {
+		t.Errorf("вычислений функции: Стеффенсен %d, бисекция %d", steffensenCalls, calls)
+	}
+
+	// У постоянной функции f(x + f(x)) - f(x) = 0
+	if _, err := steffensen(func(float64) float64 { return 1 }, 0, 1e-12, 50); err == nil {
+		t.Error("ожидалась ошибка при вырожденном знаменателе")
+	}
+
+	// Малый масштаб функции: x + f(x) == x, знаменатель нулевой, хотя до корня x = 2 далеко.
+	// Малость f(x) не означает сходимости
+	tiny := func(x float64) float64 { return 1e-17 * (x - 2) }
+	if x, err := steffensen(tiny, 1, 1e-12, 50); err == nil {
+		t.Errorf("f(x) = 1e-17(x - 2): получен корень %g без ошибки, корень равен 2", x)
+	}
+}
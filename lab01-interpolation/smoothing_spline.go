@@ -0,0 +1,101 @@
+package main
+
+import "fmt"
+
+// pointWithError представляет измерение y в точке x с погрешностью (стандартным отклонением) sigma
+type pointWithError struct {
+	x, y, sigma float64
+}
+
+// weightedSplineSmooth строит сглаживающий кубический сплайн g, минимизирующий сумму
+// sum(((y(i) - g(x(i))) / sigma(i))^2) и умноженного на lambda интеграла квадрата второй
+// производной g: точки с меньшей погрешностью приближаются точнее, lambda задает вес штрафа
+// за кривизну. При lambda = 0 получается интерполирующий естественный сплайн, при
+// lambda -> inf - взвешенная прямая наименьших квадратов. Решение - естественный сплайн по
+// сглаженным значениям g(x(i)), вторые производные которого находятся из системы
+// (R + lambda * Q^T D Q) gamma = Q^T y (алгоритм Райнша), где D = diag(sigma^2)
+func weightedSplineSmooth(points []pointWithError, lambda float64) (*cubicSpline, error) {
+	if !(lambda >= 0) {
+		return nil, fmt.Errorf("параметр сглаживания должен быть неотрицательным, получено %g", lambda)
+	}
+
+	n := len(points)
+	nodes := make([]point, n)
+	variance := make([]float64, n)
+	for i, p := range points {
+		if !(p.sigma > 0) {
+			return nil, fmt.Errorf("погрешность точки %d должна быть положительной, получено %g", i, p.sigma)
+		}
+		nodes[i] = point{x: p.x, y: p.y}
+		variance[i] = p.sigma * p.sigma
+	}
+	if err := validateSplinePoints(nodes); err != nil {
+		return nil, err
+	}
+	if n < 3 {
+		// Через две точки прямая проходит точно, сглаживать нечего
+		return newCubicSpline(&interpolationData{points: nodes, a: nodes[0].x, b: nodes[n-1].x, n: n - 1})
+	}
+
+	h := make([]float64, n-1)
+	for i := range h {
+		h[i] = nodes[i+1].x - nodes[i].x
+	}
+
+	// Q (n x m) - вторые разделенные разности, R (m x m) - матрица интеграла g''^2,
+	// столбец c соответствует внутреннему узлу k = c + 1
+	m := n - 2
+	q := newMatrix(n, m)
+	r := newMatrix(m, m)
+	for c := 0; c < m; c++ {
+		k := c + 1
+		q.set(k-1, c, 1/h[k-1])
+		q.set(k, c, -1/h[k-1]-1/h[k])
+		q.set(k+1, c, 1/h[k])
+
+		r.set(c, c, (h[k-1]+h[k])/3)
+		if c+1 < m {
+			r.set(c, c+1, h[k]/6)
+			r.set(c+1, c, h[k]/6)
+		}
+	}
+
+	// D Q: строки Q, умноженные на дисперсии
+	dq := newMatrix(n, m)
+	for i := 0; i < n; i++ {
+		copy(dq.data[i], q.data[i])
+		dq.scaleRow(i, variance[i])
+	}
+
+	qt := q.transpose()
+	a := qt.mul(dq)
+	for i := 0; i < m; i++ {
+		for j := 0; j < m; j++ {
+			a.set(i, j, r.get(i, j)+lambda*a.get(i, j))
+		}
+	}
+
+	y := make([]float64, n)
+	for i, p := range nodes {
+		y[i] = p.y
+	}
+	gamma, err := solveLinearSystem(a, qt.mulVec(y))
+	if err != nil {
+		return nil, err
+	}
+
+	// Сглаженные значения g = y - lambda * D Q gamma
+	correction := dq.mulVec(gamma)
+	for i := range nodes {
+		nodes[i].y = y[i] - lambda*correction[i]
+	}
+
+	secondDerivatives := make([]float64, n)
+	copy(secondDerivatives[1:n-1], gamma)
+
+	return &cubicSpline{
+		points:            nodes,
+		secondDerivatives: secondDerivatives,
+		h:                 h,
+	}, nil
+}
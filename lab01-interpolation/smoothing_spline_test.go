@@ -0,0 +1,83 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// noisyMeasurements возвращает зашумленные измерения sin x на [0, 3] с разной погрешностью
+func noisyMeasurements() []pointWithError {
+	rng := rand.New(rand.NewSource(11))
+	xs := linspace(0, 3, 15)
+	points := make([]pointWithError, len(xs))
+	for i, x := range xs {
+		sigma := 0.05 + 0.1*rng.Float64()
+		points[i] = pointWithError{x: x, y: math.Sin(x) + sigma*rng.NormFloat64(), sigma: sigma}
+	}
+	return points
+}
+
+func TestWeightedSplineSmoothSmallLambdaInterpolates(t *testing.T) {
+	measurements := noisyMeasurements()
+	nodes := make([]point, len(measurements))
+	for i, p := range measurements {
+		nodes[i] = point{x: p.x, y: p.y}
+	}
+	natural, err := newCubicSpline(&interpolationData{points: nodes, a: 0, b: 3, n: len(nodes) - 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, lambda := range []float64{0, 1e-10} {
+		smooth, err := weightedSplineSmooth(measurements, lambda)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e := maxError(natural.evaluate, smooth.evaluate, 0, 3, splineTestSamples); e > 1e-6 {
+			t.Errorf("lambda = %g: отличие от интерполирующего сплайна %.3e", lambda, e)
+		}
+	}
+}
+
+func TestWeightedSplineSmoothLargeLambdaIsLine(t *testing.T) {
+	measurements := noisyMeasurements()
+	nodes := make([]point, len(measurements))
+	weights := make([]float64, len(measurements))
+	for i, p := range measurements {
+		nodes[i] = point{x: p.x, y: p.y}
+		weights[i] = 1 / (p.sigma * p.sigma)
+	}
+	line, err := polyFitWeighted(nodes, weights, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lineFunc := func(x float64) float64 { return line[0] + line[1]*x }
+
+	smooth, err := weightedSplineSmooth(measurements, 1e10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := maxError(lineFunc, smooth.evaluate, 0, 3, splineTestSamples); e > 1e-6 {
+		t.Errorf("отличие от взвешенной прямой наименьших квадратов %.3e", e)
+	}
+	for i, g := range smooth.secondDerivatives {
+		if math.Abs(g) > 1e-6 {
+			t.Errorf("вторая производная в узле %d равна %g, ожидался 0", i, g)
+		}
+	}
+}
+
+func TestWeightedSplineSmoothErrors(t *testing.T) {
+	measurements := noisyMeasurements()
+	if _, err := weightedSplineSmooth(measurements, -1); err == nil {
+		t.Error("ожидалась ошибка для отрицательного lambda")
+	}
+	measurements[3].sigma = 0
+	if _, err := weightedSplineSmooth(measurements, 1); err == nil {
+		t.Error("ожидалась ошибка для нулевой погрешности")
+	}
+	if _, err := weightedSplineSmooth(measurements[:1], 1); err == nil {
+		t.Error("ожидалась ошибка для одной точки")
+	}
+}
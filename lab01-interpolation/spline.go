@@ -0,0 +1,440 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// BoundaryCondition - тип граничных условий кубического сплайна
+type BoundaryCondition int
+
+const (
+	Natural  BoundaryCondition = iota // Вторые производные на концах равны нулю
+	Clamped                           // Заданы первые производные на концах
+	NotAKnot                          // Третья производная непрерывна в крайних внутренних узлах
+	Periodic                          // Первые и вторые производные на концах совпадают
+)
+
+// String возвращает название граничных условий
+func (bc BoundaryCondition) String() string {
+	switch bc {
+	case Natural:
+		return "естественные"
+	case Clamped:
+		return "заданные производные"
+	case NotAKnot:
+		return "not-a-knot"
+	case Periodic:
+		return "периодические"
+	}
+	return fmt.Sprintf("BoundaryCondition(%d)", int(bc))
+}
+
+// newCubicSplineWithBC создает кубический сплайн с граничными условиями bc.
+// Для Clamped в params передаются производные на левом и правом концах,
+// для остальных условий params должны быть пустыми
+func newCubicSplineWithBC(data *interpolationData, bc BoundaryCondition, params ...float64) (*cubicSpline, error) {
+	wantParams := 0
+	if bc == Clamped {
+		wantParams = 2
+	}
+	if len(params) != wantParams {
+		return nil, fmt.Errorf("граничные условия \"%v\": ожидалось параметров %d, получено %d", bc, wantParams, len(params))
+	}
+
+	switch bc {
+	case Natural:
+		return newCubicSpline(data)
+	case Clamped:
+		return newClampedCubicSpline(data, params[0], params[1])
+	case NotAKnot:
+		return newNotAKnotSpline(data)
+	case Periodic:
+		return newPeriodicCubicSpline(data)
+	}
+	return nil, fmt.Errorf("неизвестные граничные условия %v", bc)
+}
+
+// newClampedCubicSpline создает кубический сплайн с заданными первыми производными
+// dStart и dEnd на концах интервала (фундаментальный сплайн)
+func newClampedCubicSpline(data *interpolationData, dStart, dEnd float64) (*cubicSpline, error) {
+	points := data.points
+	if err := validateSplinePoints(points); err != nil {
+		return nil, err
+	}
+	n := len(points)
+	sys := newSplineSystem(points)
+	h := sys.h
+
+	// S'(x0) = dStart: 2*h0*M0 + h0*M1 = 6*((y1 - y0)/h0 - dStart)
+	sys.diag[0] = 2 * h[0]
+	sys.upper[0] = h[0]
+	sys.rhs[0] = 6 * ((points[1].y-points[0].y)/h[0] - dStart)
+
+	// S'(xn) = dEnd: h(n-1)*M(n-1) + 2*h(n-1)*Mn = 6*(dEnd - (yn - y(n-1))/h(n-1))
+	sys.lower[n-1] = h[n-2]
+	sys.diag[n-1] = 2 * h[n-2]
+	sys.rhs[n-1] = 6 * (dEnd - (points[n-1].y-points[n-2].y)/h[n-2])
+
+	return sys.solve(points), nil
+}
+
+// newCubicSplineSecondDeriv создает кубический сплайн с заданными вторыми производными
+// d2Start и d2End на концах интервала. При d2Start = d2End = 0 совпадает с естественным
+// сплайном newCubicSpline
+func newCubicSplineSecondDeriv(data *interpolationData, d2Start, d2End float64) (*cubicSpline, error) {
+	points := data.points
+	if err := validateSplinePoints(points); err != nil {
+		return nil, err
+	}
+	n := len(points)
+	sys := newSplineSystem(points)
+
+	// M0 = d2Start, Mn = d2End
+	sys.diag[0] = 1
+	sys.rhs[0] = d2Start
+	sys.diag[n-1] = 1
+	sys.rhs[n-1] = d2End
+
+	return sys.solve(points), nil
+}
+
+// newNotAKnotSpline создает кубический сплайн с условиями "not-a-knot": третья производная
+// непрерывна в первом и последнем внутренних узлах, т.е. два крайних отрезка с каждой
+// стороны описываются одним кубическим полиномом
+func newNotAKnotSpline(data *interpolationData) (*cubicSpline, error) {
+	points := data.points
+	if err := validateSplinePoints(points); err != nil {
+		return nil, err
+	}
+	n := len(points)
+
+	switch {
+	case n < 3:
+		// Через две точки проходит только прямая
+		return newCubicSpline(data)
+	case n == 3:
+		// Через три точки проходит одна парабола: вторая производная постоянна
+		h0 := points[1].x - points[0].x
+		h1 := points[2].x - points[1].x
+		m := 2 * ((points[2].y-points[1].y)/h1 - (points[1].y-points[0].y)/h0) / (h0 + h1)
+		return &cubicSpline{
+			points:            points,
+			secondDerivatives: []float64{m, m, m},
+			h:                 []float64{h0, h1},
+		}, nil
+	}
+
+	sys := newSplineSystem(points)
+	h := sys.h
+
+	// Условие в x1: (M1 - M0)/h0 = (M2 - M1)/h1, откуда M0 = ((h0 + h1)*M1 - h0*M2)/h1.
+	// Подставляем M0 в уравнение для узла 1, сохраняя трехдиагональный вид системы
+	h0, h1 := h[0], h[1]
+	sys.diag[1] = (h0 + h1) * (h0 + 2*h1) / h1
+	sys.upper[1] = (h1*h1 - h0*h0) / h1
+	sys.lower[1] = 0
+
+	// Симметричное условие в x(n-2): исключаем M(n-1) из уравнения для узла n-2
+	hl, hr := h[n-3], h[n-2]
+	sys.lower[n-2] = (hl*hl - hr*hr) / hl
+	sys.diag[n-2] = (hl + hr) * (2*hl + hr) / hl
+	sys.upper[n-2] = 0
+
+	// Решаем укороченную систему для M1..M(n-2) и восстанавливаем крайние значения
+	inner := solveTridiagonal(sys.lower[1:n-1], sys.diag[1:n-1], sys.upper[1:n-1], sys.rhs[1:n-1])
+	m := make([]float64, n)
+	copy(m[1:n-1], inner)
+	m[0] = ((h0+h1)*m[1] - h0*m[2]) / h1
+	m[n-1] = ((hl+hr)*m[n-2] - hr*m[n-3]) / hl
+
+	return &cubicSpline{
+		points:            points,
+		secondDerivatives: m,
+		h:                 h,
+	}, nil
+}
+
+// periodicTolerance - допустимое расхождение значений в крайних узлах периодического сплайна
+// относительно наибольшего по модулю значения в узлах
+const periodicTolerance = 1e-9
+
+// newPeriodicCubicSpline создает периодический кубический сплайн: первые и вторые производные
+// на концах совпадают. Значения в крайних узлах должны совпадать (f(a) == f(b)) с точностью
+// periodicTolerance, иначе возвращается ошибка; оставшееся расхождение округления устраняется
+// тем, что значение в последнем узле берется равным первому
+func newPeriodicCubicSpline(data *interpolationData) (*cubicSpline, error) {
+	points := data.points
+	if err := validateSplinePoints(points); err != nil {
+		return nil, err
+	}
+	n := len(points)
+
+	scale := 0.0
+	for _, p := range points {
+		scale = math.Max(scale, math.Abs(p.y))
+	}
+	if diff := math.Abs(points[n-1].y - points[0].y); diff > periodicTolerance*scale {
+		return nil, fmt.Errorf("периодический сплайн требует равных значений на концах: y[0] = %g, y[%d] = %g",
+			points[0].y, n-1, points[n-1].y)
+	}
+	if n < 3 {
+		return newCubicSpline(data)
+	}
+
+	// Неизвестные M0..M(n-2), так как M(n-1) = M0
+	m := n - 1
+	h := make([]float64, m)
+	y := make([]float64, n)
+	for i := 0; i < m; i++ {
+		h[i] = points[i+1].x - points[i].x
+		y[i] = points[i].y
+	}
+	y[m] = y[0]
+
+	lower := make([]float64, m)
+	diag := make([]float64, m)
+	upper := make([]float64, m)
+	b := make([]float64, m)
+
+	// Уравнения для всех узлов с циклической нумерацией отрезков
+	for i := 0; i < m; i++ {
+		prev := (i - 1 + m) % m
+		lower[i] = h[prev]
+		diag[i] = 2 * (h[prev] + h[i])
+		upper[i] = h[i]
+		b[i] = 6 * ((y[i+1]-y[i])/h[i] - (y[prev+1]-y[prev])/h[prev])
+	}
+
+	solution, err := solveCyclicTridiagonal(lower, diag, upper, b)
+	if err != nil {
+		return nil, err
+	}
+	secondDerivatives := make([]float64, n)
+	copy(secondDerivatives, solution)
+	secondDerivatives[m] = solution[0]
+
+	periodicPoints := make([]point, n)
+	copy(periodicPoints, points)
+	periodicPoints[m].y = y[0]
+
+	return &cubicSpline{
+		points:            periodicPoints,
+		secondDerivatives: secondDerivatives,
+		h:                 h,
+	}, nil
+}
+
+// evaluateBatch вычисляет значения сплайна во всех точках xs за O(n + m log m): точки
+// упорядочиваются, после чего отрезки просматриваются одним проходом вместо бинарного
+// поиска для каждой точки. Результаты возвращаются в исходном порядке xs и совпадают с evaluate
+func (cs *cubicSpline) evaluateBatch(xs []float64) []float64 {
+	order := make([]int, len(xs))
+	for k := range order {
+		order[k] = k
+	}
+	sort.Slice(order, func(p, q int) bool {
+		return xs[order[p]] < xs[order[q]]
+	})
+
+	values := make([]float64, len(xs))
+	last := len(cs.points) - 2
+	i := 0
+	for _, k := range order {
+		x := xs[k]
+		// Тот же выбор отрезка, что и в findInterval: первый отрезок с x <= x(i+1)
+		for i < last && x > cs.points[i+1].x {
+			i++
+		}
+		values[k] = cs.evaluateOn(i, x)
+	}
+	return values
+}
+
+// sample возвращает numPoints равноотстоящих точек отрезка между крайними узлами
+// (включая концы) и значения сплайна в них. При numPoints < 2 возвращает пустые срезы
+func (cs *cubicSpline) sample(numPoints int) ([]float64, []float64) {
+	if numPoints < 2 || len(cs.points) < 2 {
+		return nil, nil
+	}
+
+	lo, hi := cs.domain()
+	xs := linspace(lo, hi, numPoints)
+	return xs, cs.evaluateBatch(xs)
+}
+
+// evaluateDerivative вычисляет первую производную сплайна в точке x,
+// дифференцируя формулу (2.61) на соответствующем отрезке
+func (cs *cubicSpline) evaluateDerivative(x float64) float64 {
+	i := findInterval(cs.points, x)
+
+	xi := cs.points[i].x
+	xi1 := cs.points[i+1].x
+	yi := cs.points[i].y
+	yi1 := cs.points[i+1].y
+	hi1 := cs.h[i]
+	gammai := cs.secondDerivatives[i]
+	gammai1 := cs.secondDerivatives[i+1]
+
+	xi1minusx := xi1 - x
+	xminusxi := x - xi
+
+	term12 := (yi1 - yi) / hi1
+	term3 := gammai * (hi1*hi1 - 3*xi1minusx*xi1minusx) / (6 * hi1)
+	term4 := gammai1 * (3*xminusxi*xminusxi - hi1*hi1) / (6 * hi1)
+
+	return term12 + term3 + term4
+}
+
+// evaluateSecondDerivative вычисляет вторую производную сплайна в точке x - линейную
+// интерполяцию значений secondDerivatives на соответствующем отрезке
+func (cs *cubicSpline) evaluateSecondDerivative(x float64) float64 {
+	i := findInterval(cs.points, x)
+
+	hi1 := cs.h[i]
+	return (cs.secondDerivatives[i]*(cs.points[i+1].x-x) + cs.secondDerivatives[i+1]*(x-cs.points[i].x)) / hi1
+}
+
+// curvature вычисляет кривизну графика сплайна k = S"(x) / (1 + S'(x)^2)^(3/2) в точке x.
+// Знак совпадает со знаком второй производной: смена знака указывает на точку перегиба
+func (cs *cubicSpline) curvature(x float64) float64 {
+	d1 := cs.evaluateDerivative(x)
+	return cs.evaluateSecondDerivative(x) / math.Pow(1+d1*d1, 1.5)
+}
+
+// coefficients возвращает коэффициенты сплайна по отрезкам в степенной форме: на отрезке
+// [x(i), x(i+1)] сплайн равен a + b*t + c*t^2 + d*t^3, где t = x - x(i), а элемент i
+// результата - {a, b, c, d}. Коэффициенты получаются раскрытием формулы (2.61)
+func (cs *cubicSpline) coefficients() [][4]float64 {
+	if len(cs.points) < 2 {
+		return nil
+	}
+
+	coeffs := make([][4]float64, len(cs.points)-1)
+	for i := range coeffs {
+		hi1 := cs.h[i]
+		gammai := cs.secondDerivatives[i]
+		gammai1 := cs.secondDerivatives[i+1]
+
+		coeffs[i] = [4]float64{
+			cs.points[i].y,
+			(cs.points[i+1].y-cs.points[i].y)/hi1 - hi1*(2*gammai+gammai1)/6,
+			gammai / 2,
+			(gammai1 - gammai) / (6 * hi1),
+		}
+	}
+	return coeffs
+}
+
+// integrate вычисляет точный определенный интеграл сплайна от x0 до x1, суммируя
+// интегралы по отрезкам в замкнутой форме. Вне [x0, xn] интегрируются крайние полиномы
+func (cs *cubicSpline) integrate(x0, x1 float64) float64 {
+	if x0 > x1 {
+		return -cs.integrate(x1, x0)
+	}
+
+	n := len(cs.points)
+	result := 0.0
+	for i := 0; i < n-1; i++ {
+		// Пересечение [x0, x1] с отрезком i; крайние отрезки продолжаются за пределы узлов
+		lo := math.Max(x0, cs.points[i].x)
+		if i == 0 {
+			lo = x0
+		}
+		hi := math.Min(x1, cs.points[i+1].x)
+		if i == n-2 {
+			hi = x1
+		}
+		if lo < hi {
+			result += cs.antiderivative(i, hi) - cs.antiderivative(i, lo)
+		}
+	}
+
+	return result
+}
+
+// antiderivative вычисляет первообразную формулы (2.61) на отрезке i в точке x
+func (cs *cubicSpline) antiderivative(i int, x float64) float64 {
+	xi := cs.points[i].x
+	xi1 := cs.points[i+1].x
+	yi := cs.points[i].y
+	yi1 := cs.points[i+1].y
+	hi1 := cs.h[i]
+	gammai := cs.secondDerivatives[i]
+	gammai1 := cs.secondDerivatives[i+1]
+
+	v := xi1 - x
+	u := x - xi
+	v2 := v * v
+	u2 := u * u
+	h2 := hi1 * hi1
+
+	term1 := -yi * v2 / (2 * hi1)
+	term2 := yi1 * u2 / (2 * hi1)
+	term3 := -gammai * (v2*v2/4 - h2*v2/2) / (6 * hi1)
+	term4 := gammai1 * (u2*u2/4 - h2*u2/2) / (6 * hi1)
+
+	return term1 + term2 + term3 + term4
+}
+
+// quadraticSpline представляет квадратичный сплайн дефекта 1: на отрезке i
+// S(x) = y(i) + b(i)*(x - x(i)) + c(i)*(x - x(i))^2, значения и первые производные непрерывны
+type quadraticSpline struct {
+	points []point
+	b, c   []float64 // Коэффициенты при (x - x(i)) и (x - x(i))^2 на каждом отрезке
+}
+
+// newQuadraticSpline создает квадратичный сплайн. Недостающее граничное условие - наклон
+// в левом конце берется равным наклону параболы через первые три узла, поэтому
+// любой квадратичный полином восстанавливается точно
+func newQuadraticSpline(data *interpolationData) (*quadraticSpline, error) {
+	points := data.points
+	if err := validateSplinePoints(points); err != nil {
+		return nil, err
+	}
+	n := len(points)
+
+	h := make([]float64, n-1)
+	delta := make([]float64, n-1)
+	for i := 0; i < n-1; i++ {
+		h[i] = points[i+1].x - points[i].x
+		delta[i] = (points[i+1].y - points[i].y) / h[i]
+	}
+
+	b := make([]float64, n-1)
+	c := make([]float64, n-1)
+
+	// Граничное условие: S'(x0) = P'(x0), P - парабола через x0, x1, x2
+	b[0] = delta[0]
+	if n > 2 {
+		b[0] = delta[0] - h[0]*(delta[1]-delta[0])/(h[0]+h[1])
+	}
+
+	// Из S(x(i+1)) = y(i+1) находим c(i), из непрерывности производной - b(i+1)
+	for i := 0; i < n-1; i++ {
+		c[i] = (delta[i] - b[i]) / h[i]
+		if i < n-2 {
+			b[i+1] = b[i] + 2*c[i]*h[i]
+		}
+	}
+
+	return &quadraticSpline{points: points, b: b, c: c}, nil
+}
+
+// evaluate вычисляет значение квадратичного сплайна в точке x
+func (qs *quadraticSpline) evaluate(x float64) float64 {
+	i := findInterval(qs.points, x)
+	dx := x - qs.points[i].x
+	return qs.points[i].y + qs.b[i]*dx + qs.c[i]*dx*dx
+}
+
+// domain возвращает отрезок между крайними узлами сплайна
+func (cs *cubicSpline) domain() (lo, hi float64) {
+	return nodeRange(cs.points)
+}
+
+// domain возвращает отрезок между крайними узлами сплайна
+func (qs *quadraticSpline) domain() (lo, hi float64) {
+	return nodeRange(qs.points)
+}
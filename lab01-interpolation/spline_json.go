@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// splineJSON - представление кубического сплайна в JSON: узлы и вторые производные в узлах.
+// Этого достаточно, чтобы вычислять сплайн без повторного решения системы; шаги h
+// однозначно определяются узлами и не сохраняются
+type splineJSON struct {
+	Points            []resultPoint `json:"points"`
+	SecondDerivatives []float64     `json:"secondDerivatives"`
+}
+
+// MarshalJSON сохраняет сплайн в JSON (см. splineJSON)
+func (cs *cubicSpline) MarshalJSON() ([]byte, error) {
+	points := make([]resultPoint, len(cs.points))
+	for i, p := range cs.points {
+		points[i] = resultPoint{X: p.x, Y: p.y}
+	}
+	return json.Marshal(splineJSON{
+		Points:            points,
+		SecondDerivatives: cs.secondDerivatives,
+	})
+}
+
+// UnmarshalJSON восстанавливает сплайн, сохраненный MarshalJSON. Узлы проверяются так же,
+// как при построении сплайна, шаги h вычисляются по ним заново, а вторых производных должно
+// быть столько же, сколько узлов
+func (cs *cubicSpline) UnmarshalJSON(data []byte) error {
+	var s splineJSON
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	points := make([]point, len(s.Points))
+	for i, p := range s.Points {
+		points[i] = point{x: p.X, y: p.Y}
+	}
+	if err := validateSplinePoints(points); err != nil {
+		return err
+	}
+	n := len(points)
+	if len(s.SecondDerivatives) != n {
+		return fmt.Errorf("ожидалось %d вторых производных, получено %d", n, len(s.SecondDerivatives))
+	}
+
+	h := make([]float64, n-1)
+	for i := range h {
+		h[i] = points[i+1].x - points[i].x
+	}
+
+	cs.points = points
+	cs.h = h
+	cs.secondDerivatives = s.SecondDerivatives
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSplineJSONRoundTrip(t *testing.T) {
+	data, err := createChebyshevGrid2(1, 5, 12, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spline, err := newNotAKnotSpline(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err := json.Marshal(spline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded cubicSpline
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(decoded.h, spline.h) {
+		t.Errorf("шаги после восстановления %v, ожидалось %v", decoded.h, spline.h)
+	}
+	for _, x := range linspace(0.5, 5.5, splineTestSamples) {
+		if got, want := decoded.evaluate(x), spline.evaluate(x); got != want {
+			t.Errorf("S(%g) = %.17g после восстановления, %.17g до", x, got, want)
+		}
+	}
+}
+
+func TestSplineJSONIgnoresStoredSteps(t *testing.T) {
+	// Шаги h не хранятся: устаревшее поле с неверными значениями не влияет на сплайн
+	var spline cubicSpline
+	input := `{"points": [{"x": 0, "y": 1}, {"x": 1, "y": 3}, {"x": 3, "y": 2}],
+		"h": [10, 10], "secondDerivatives": [0, -1, 0]}`
+	if err := json.Unmarshal([]byte(input), &spline); err != nil {
+		t.Fatal(err)
+	}
+	if want := []float64{1, 2}; !slices.Equal(spline.h, want) {
+		t.Errorf("шаги %v, ожидалось %v", spline.h, want)
+	}
+
+	encoded, err := json.Marshal(&spline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(encoded), `"h"`) {
+		t.Errorf("в JSON сохранены шаги: %s", encoded)
+	}
+}
+
+func TestSplineJSONErrors(t *testing.T) {
+	for _, input := range []string{
+		`{"points": [{"x": 0, "y": 1}, {"x": 1, "y": 3}], "secondDerivatives": [0]}`,
+		`{"points": [{"x": 1, "y": 1}, {"x": 0, "y": 3}], "secondDerivatives": [0, 0]}`,
+		`{"points": [{"x": 0, "y": 1}], "secondDerivatives": [0]}`,
+		`{"points": 5}`,
+	} {
+		var spline cubicSpline
+		if err := json.Unmarshal([]byte(input), &spline); err == nil {
+			t.Errorf("%s: ожидалась ошибка", input)
+		}
+	}
+}
@@ -0,0 +1,504 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// splineTestSamples - количество точек, по которым в тестах оценивается ошибка сплайнов
+const splineTestSamples = 1000
+
+func TestClampedSplineReducesError(t *testing.T) {
+	for _, n := range []int{5, 10, 20} {
+		data, err := createGrid(1, 5, n, testFunction)
+		if err != nil {
+			t.Fatal(err)
+		}
+		natural, err := newCubicSpline(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		clamped, err := newClampedCubicSpline(data, testFunctionDerivative(1), testFunctionDerivative(5))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		naturalErr := maxError(testFunction, natural.evaluate, 1, 5, splineTestSamples)
+		clampedErr := maxError(testFunction, clamped.evaluate, 1, 5, splineTestSamples)
+		// Ошибка естественного сплайна сосредоточена у концов, где f" != 0
+		if !(clampedErr < naturalErr/10) {
+			t.Errorf("N = %d: ошибка сплайна с заданными производными %.3e не намного меньше ошибки естественного %.3e",
+				n, clampedErr, naturalErr)
+		}
+	}
+}
+
+// testCubic - кубический полином, который должны точно восстанавливать сплайны с условиями not-a-knot
+func testCubic(x float64) float64 {
+	return 2 - x + 0.5*x*x + 0.3*x*x*x
+}
+
+func TestNotAKnotReproducesCubic(t *testing.T) {
+	grids := [][]float64{
+		{1, 2, 3, 4},
+		{1, 1.5, 2, 2.5, 3, 3.5, 4, 4.5, 5},
+		{-2, -1.7, -0.4, 0, 0.3, 1.9, 2.2, 3},
+	}
+
+	for _, xs := range grids {
+		data, err := sampleAt(xs, testCubic)
+		if err != nil {
+			t.Fatal(err)
+		}
+		spline, err := newNotAKnotSpline(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e := maxError(testCubic, spline.evaluate, data.a, data.b, splineTestSamples); e > 1e-9 {
+			t.Errorf("узлы %v: ошибка not-a-knot сплайна на кубическом полиноме %.3e", xs, e)
+		}
+
+		// У естественного сплайна S" = 0 на концах, а у полинома - нет
+		natural, err := newCubicSpline(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e := maxError(testCubic, natural.evaluate, data.a, data.b, splineTestSamples); e < 1e-6 {
+			t.Errorf("узлы %v: естественный сплайн неожиданно восстановил кубический полином (ошибка %.3e)", xs, e)
+		}
+	}
+}
+
+func TestPeriodicSplineWrapAround(t *testing.T) {
+	for _, n := range []int{4, 8, 16, 32} {
+		data, err := createGrid(0, 2*math.Pi, n, math.Sin)
+		if err != nil {
+			t.Fatal(err)
+		}
+		spline, err := newPeriodicCubicSpline(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Продолжение сплайна за правый конец должно переходить в его начало
+		a, b := data.a, data.b
+		if d0, d1 := spline.evaluateDerivative(a), spline.evaluateDerivative(b); math.Abs(d0-d1) > 1e-10 {
+			t.Errorf("N = %d: S'(0) = %g, S'(2pi) = %g", n, d0, d1)
+		}
+		if d0, d1 := spline.evaluateSecondDerivative(a), spline.evaluateSecondDerivative(b); math.Abs(d0-d1) > 1e-10 {
+			t.Errorf("N = %d: вторые производные на концах %g и %g", n, d0, d1)
+		}
+		if n >= 16 {
+			if e := maxError(math.Sin, spline.evaluate, a, b, splineTestSamples); e > 1e-3 {
+				t.Errorf("N = %d: ошибка периодического сплайна на sin %.3e", n, e)
+			}
+		}
+	}
+}
+
+func TestPeriodicSplineRejectsNonPeriodicData(t *testing.T) {
+	data, err := createGrid(0, 2*math.Pi, 10, func(x float64) float64 { return math.Sin(x) + x/10 })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newPeriodicCubicSpline(data); err == nil {
+		t.Error("ожидалась ошибка для f(a) != f(b)")
+	}
+	if _, err := newCubicSplineWithBC(data, Periodic); err == nil {
+		t.Error("newCubicSplineWithBC(Periodic): ожидалась ошибка для f(a) != f(b)")
+	}
+}
+
+func TestSplineDerivativeMatchesFiniteDifference(t *testing.T) {
+	data, err := createGrid(1, 5, 10, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spline, err := newCubicSpline(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const step = 1e-5
+	for _, x := range linspace(data.a+step, data.b-step, splineTestSamples) {
+		fd := (spline.evaluate(x+step) - spline.evaluate(x-step)) / (2 * step)
+		if d := spline.evaluateDerivative(x); math.Abs(d-fd) > 1e-5 {
+			t.Errorf("S'(%g) = %.10g, центральная разность %.10g", x, d, fd)
+		}
+	}
+
+	// Во внутренних узлах производные соседних отрезков совпадают
+	for _, p := range spline.points[1 : len(spline.points)-1] {
+		left, right := spline.evaluateDerivative(p.x-1e-9), spline.evaluateDerivative(p.x+1e-9)
+		if math.Abs(left-right) > 1e-6 {
+			t.Errorf("разрыв производной в узле %g: слева %.10g, справа %.10g", p.x, left, right)
+		}
+	}
+}
+
+func TestSplineIntegrateCubic(t *testing.T) {
+	// Первообразная testCubic
+	antiderivative := func(x float64) float64 {
+		return 2*x - x*x/2 + x*x*x/6 + 0.075*x*x*x*x
+	}
+	data, err := sampleAt([]float64{-2, -1.7, -0.4, 0, 0.3, 1.9, 2.2, 3}, testCubic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spline, err := newNotAKnotSpline(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range [][2]float64{
+		{-2, 3},     // Весь отрезок
+		{-1.9, 2.5}, // Неполные крайние отрезки
+		{0.1, 0.2},  // Внутри одного отрезка
+		{0, 1.9},    // Границы в узлах
+		{2.5, -1.2}, // Обратный порядок
+		{1, 1},
+	} {
+		x0, x1 := r[0], r[1]
+		want := antiderivative(x1) - antiderivative(x0)
+		if got := spline.integrate(x0, x1); math.Abs(got-want) > 1e-9 {
+			t.Errorf("интеграл по [%g, %g] = %.12g, ожидалось %.12g", x0, x1, got, want)
+		}
+		if got, rev := spline.integrate(x0, x1), spline.integrate(x1, x0); got != -rev {
+			t.Errorf("интеграл по [%g, %g] = %g, в обратную сторону %g", x0, x1, got, rev)
+		}
+	}
+}
+
+func TestQuadraticSplineReproducesQuadratic(t *testing.T) {
+	quadratics := []func(float64) float64{
+		func(x float64) float64 { return 3 },
+		func(x float64) float64 { return 1 - 2*x },
+		func(x float64) float64 { return 0.5 + x - 0.7*x*x },
+	}
+	grids := [][]float64{
+		{0, 1, 2},
+		{-2, -1.7, -0.4, 0, 0.3, 1.9, 2.2, 3},
+	}
+
+	for i, f := range quadratics {
+		for _, xs := range grids {
+			data, err := sampleAt(xs, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			spline, err := newQuadraticSpline(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if e := maxError(f, spline.evaluate, data.a, data.b, splineTestSamples); e > 1e-10 {
+				t.Errorf("полином %d, узлы %v: ошибка квадратичного сплайна %.3e", i, xs, e)
+			}
+		}
+	}
+}
+
+func TestDefaultInterpolatorsIncludeQuadraticSpline(t *testing.T) {
+	methods := testInterpolators(t, 10)
+	for _, m := range methods {
+		if m.Name() == "Кв. сплайн" {
+			return
+		}
+	}
+	t.Error("среди методов сравнения нет квадратичного сплайна")
+}
+
+func TestSplineEvaluateBatchPreservesOrder(t *testing.T) {
+	data, err := createGrid(1, 5, 12, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spline, err := newCubicSpline(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Перемешанные точки, включая узлы, повторы и точки вне [a, b]
+	xs := linspace(0, 6, 301)
+	xs = append(xs, 2, 2, 1, 5)
+	rng := rand.New(rand.NewSource(3))
+	rng.Shuffle(len(xs), func(i, j int) { xs[i], xs[j] = xs[j], xs[i] })
+	queries := slices.Clone(xs)
+
+	values := spline.evaluateBatch(xs)
+	if !slices.Equal(xs, queries) {
+		t.Error("evaluateBatch изменил срез точек")
+	}
+	if len(values) != len(xs) {
+		t.Fatalf("%d значений для %d точек", len(values), len(xs))
+	}
+	for i, x := range xs {
+		if values[i] != spline.evaluate(x) {
+			t.Errorf("x = %g: evaluateBatch дает %.17g, evaluate %.17g", x, values[i], spline.evaluate(x))
+		}
+	}
+}
+
+func TestSplineSecondDerivativeAndCurvature(t *testing.T) {
+	data, err := createGrid(1, 5, 10, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spline, err := newCubicSpline(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// У естественного сплайна S" = 0 на концах
+	for _, x := range []float64{data.a, data.b} {
+		if d2 := spline.evaluateSecondDerivative(x); math.Abs(d2) > 1e-12 {
+			t.Errorf("S\"(%g) = %g, ожидался 0", x, d2)
+		}
+		if k := spline.curvature(x); math.Abs(k) > 1e-12 {
+			t.Errorf("кривизна в %g равна %g, ожидался 0", x, k)
+		}
+	}
+
+	// Внутри отрезка S" согласуется с разностью первых производных, а кривизна - с формулой
+	const step = 1e-6
+	for _, x := range linspace(data.a+0.01, data.b-0.01, 50) {
+		d1, d2 := spline.evaluateDerivative(x), spline.evaluateSecondDerivative(x)
+		fd := (spline.evaluateDerivative(x+step) - spline.evaluateDerivative(x-step)) / (2 * step)
+		if math.Abs(d2-fd) > 1e-5 {
+			t.Errorf("S\"(%g) = %.10g, центральная разность S' %.10g", x, d2, fd)
+		}
+		if k, want := spline.curvature(x), d2/math.Pow(1+d1*d1, 1.5); math.Abs(k-want) > 1e-15 {
+			t.Errorf("кривизна в %g равна %g, ожидалось %g", x, k, want)
+		}
+	}
+}
+
+func TestCubicSplineWithBC(t *testing.T) {
+	data, err := createGrid(0, 2*math.Pi, 12, math.Sin)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		bc     BoundaryCondition
+		params []float64
+		direct func() (*cubicSpline, error)
+	}{
+		{Natural, nil, func() (*cubicSpline, error) { return newCubicSpline(data) }},
+		{Clamped, []float64{1, 1}, func() (*cubicSpline, error) { return newClampedCubicSpline(data, 1, 1) }},
+		{NotAKnot, nil, func() (*cubicSpline, error) { return newNotAKnotSpline(data) }},
+		{Periodic, nil, func() (*cubicSpline, error) { return newPeriodicCubicSpline(data) }},
+	}
+	for _, tt := range tests {
+		spline, err := newCubicSplineWithBC(data, tt.bc, tt.params...)
+		if err != nil {
+			t.Errorf("%v: %v", tt.bc, err)
+			continue
+		}
+		want, err := tt.direct()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(spline.secondDerivatives, want.secondDerivatives) {
+			t.Errorf("%v: вторые производные %v, ожидалось %v", tt.bc, spline.secondDerivatives, want.secondDerivatives)
+		}
+		if e := maxError(math.Sin, spline.evaluate, data.a, data.b, splineTestSamples); e > 1e-2 {
+			t.Errorf("%v: ошибка на sin %.3e", tt.bc, e)
+		}
+	}
+
+	if _, err := newCubicSplineWithBC(data, Clamped, 1); err == nil {
+		t.Error("Clamped с одним параметром: ожидалась ошибка")
+	}
+	if _, err := newCubicSplineWithBC(data, Natural, 0); err == nil {
+		t.Error("Natural с лишним параметром: ожидалась ошибка")
+	}
+	if _, err := newCubicSplineWithBC(data, BoundaryCondition(42)); err == nil {
+		t.Error("неизвестные граничные условия: ожидалась ошибка")
+	}
+}
+
+func TestSplinesOnTinyData(t *testing.T) {
+	build := map[string]func(*interpolationData) (func(float64) float64, error){
+		"natural": func(d *interpolationData) (func(float64) float64, error) {
+			s, err := newCubicSpline(d)
+			if err != nil {
+				return nil, err
+			}
+			return s.evaluate, nil
+		},
+		"not-a-knot": func(d *interpolationData) (func(float64) float64, error) {
+			s, err := newNotAKnotSpline(d)
+			if err != nil {
+				return nil, err
+			}
+			return s.evaluate, nil
+		},
+		"quadratic": func(d *interpolationData) (func(float64) float64, error) {
+			s, err := newQuadraticSpline(d)
+			if err != nil {
+				return nil, err
+			}
+			return s.evaluate, nil
+		},
+		"pchip": func(d *interpolationData) (func(float64) float64, error) {
+			s, err := newPCHIP(d)
+			if err != nil {
+				return nil, err
+			}
+			return s.evaluate, nil
+		},
+		"akima": func(d *interpolationData) (func(float64) float64, error) {
+			s, err := newAkimaSpline(d)
+			if err != nil {
+				return nil, err
+			}
+			return s.evaluate, nil
+		},
+		"linear": func(d *interpolationData) (func(float64) float64, error) {
+			l, err := newLinearInterpolator(d)
+			if err != nil {
+				return nil, err
+			}
+			return l.Evaluate, nil
+		},
+	}
+
+	for name, b := range build {
+		for _, points := range [][]point{nil, {{1, 2}}} {
+			if _, err := b(&interpolationData{points: points}); err == nil {
+				t.Errorf("%s, %d узлов: ожидалась ошибка", name, len(points))
+			}
+		}
+
+		// По двум узлам любой сплайн - отрезок прямой y = 2x + 1
+		eval, err := b(&interpolationData{points: []point{{1, 3}, {3, 7}}, a: 1, b: 3, n: 1})
+		if err != nil {
+			t.Errorf("%s, 2 узла: %v", name, err)
+			continue
+		}
+		for _, x := range []float64{1, 1.5, 2, 2.75, 3} {
+			if got := eval(x); math.Abs(got-(2*x+1)) > 1e-12 {
+				t.Errorf("%s, 2 узла: S(%g) = %g, ожидалось %g", name, x, got, 2*x+1)
+			}
+		}
+	}
+
+	// Вырожденные сплайны, собранные в обход конструктора, не паникуют
+	if got := (&cubicSpline{}).evaluate(1); !math.IsNaN(got) {
+		t.Errorf("сплайн без узлов: %g, ожидалось NaN", got)
+	}
+	if got := (&cubicSpline{points: []point{{1, 2}}, secondDerivatives: []float64{0}}).evaluate(5); got != 2 {
+		t.Errorf("сплайн по одному узлу: %g, ожидалось 2", got)
+	}
+}
+
+func TestSplineCoefficientsMatchEvaluate(t *testing.T) {
+	data, err := createChebyshevGrid2(1, 5, 12, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spline, err := newClampedCubicSpline(data, testFunctionDerivative(1), testFunctionDerivative(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	coeffs := spline.coefficients()
+	if len(coeffs) != len(data.points)-1 {
+		t.Fatalf("%d наборов коэффициентов для %d отрезков", len(coeffs), len(data.points)-1)
+	}
+	for i, c := range coeffs {
+		if c[0] != data.points[i].y {
+			t.Errorf("отрезок %d: a = %g, ожидалось y(i) = %g", i, c[0], data.points[i].y)
+		}
+	}
+
+	rng := rand.New(rand.NewSource(3))
+	for range splineTestSamples {
+		x := 1 + 4*rng.Float64()
+		i := findInterval(data.points, x)
+		dx := x - data.points[i].x
+		c := coeffs[i]
+		if got, want := c[0]+dx*(c[1]+dx*(c[2]+dx*c[3])), spline.evaluate(x); math.Abs(got-want) > 1e-10 {
+			t.Errorf("x = %g: по коэффициентам %.15g, evaluate %.15g", x, got, want)
+		}
+	}
+
+	if got := (&cubicSpline{points: []point{{1, 2}}}).coefficients(); got != nil {
+		t.Errorf("коэффициенты сплайна по одному узлу %v, ожидалось nil", got)
+	}
+}
+
+func TestSplineSample(t *testing.T) {
+	data, err := createChebyshevGrid(1, 5, 10, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spline, err := newCubicSpline(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const numPoints = 57
+	xs, ys := spline.sample(numPoints)
+	if len(xs) != numPoints || len(ys) != numPoints {
+		t.Fatalf("длины %d и %d, ожидалось %d", len(xs), len(ys), numPoints)
+	}
+	// Узлы Чебышева не включают концы отрезка: выборка идет между крайними узлами
+	first, last := data.points[0], data.points[len(data.points)-1]
+	if xs[0] != first.x || xs[numPoints-1] != last.x {
+		t.Errorf("выборка от %g до %g, ожидалось от %g до %g", xs[0], xs[numPoints-1], first.x, last.x)
+	}
+	if math.Abs(ys[0]-first.y) > 1e-14 || math.Abs(ys[numPoints-1]-last.y) > 1e-14 {
+		t.Errorf("значения на концах %g и %g, ожидалось %g и %g", ys[0], ys[numPoints-1], first.y, last.y)
+	}
+	step := (last.x - first.x) / (numPoints - 1)
+	for i := 1; i < numPoints; i++ {
+		if math.Abs(xs[i]-xs[i-1]-step) > 1e-12 || ys[i] != spline.evaluate(xs[i]) {
+			t.Errorf("точка %d: (%g, %g)", i, xs[i], ys[i])
+		}
+	}
+
+	if xs, ys := spline.sample(1); xs != nil || ys != nil {
+		t.Errorf("sample(1) = %v, %v, ожидались пустые срезы", xs, ys)
+	}
+}
+
+func TestCubicSplineSecondDeriv(t *testing.T) {
+	data, err := createGrid(-1, 2, 9, testCubic)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	natural, err := newCubicSpline(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zero, err := newCubicSplineSecondDeriv(data, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(zero.secondDerivatives, natural.secondDerivatives) {
+		t.Errorf("при нулевых вторых производных %v, у естественного сплайна %v",
+			zero.secondDerivatives, natural.secondDerivatives)
+	}
+
+	// С точными вторыми производными testCubic'' = 1 + 1.8x на концах кубический полином восстанавливается
+	d2 := func(x float64) float64 { return 1 + 1.8*x }
+	exact, err := newCubicSplineSecondDeriv(data, d2(-1), d2(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := len(exact.secondDerivatives) - 1
+	if exact.secondDerivatives[0] != d2(-1) || exact.secondDerivatives[n] != d2(2) {
+		t.Errorf("вторые производные на концах %g и %g, ожидалось %g и %g",
+			exact.secondDerivatives[0], exact.secondDerivatives[n], d2(-1), d2(2))
+	}
+	if e := maxError(testCubic, exact.evaluate, -1, 2, splineTestSamples); e > 1e-12 {
+		t.Errorf("ошибка на кубическом полиноме %.3e", e)
+	}
+	if e := maxError(testCubic, natural.evaluate, -1, 2, splineTestSamples); !(e > 1e-4) {
+		t.Errorf("естественный сплайн неожиданно точен на кубическом полиноме: %.3e", e)
+	}
+}
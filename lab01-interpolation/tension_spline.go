@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// tensionSmallSigma - порог параметра натяжения на отрезке, ниже которого гиперболические
+// выражения заменяются рядами Тейлора во избежание потери точности при вычитании
+const tensionSmallSigma = 1e-2
+
+// tensionSpline представляет сплайн с натяжением: на каждом отрезке функция S"(x) - tau^2 S(x)
+// линейна, т.е. сплайн составлен из гиперболических функций. В узлах хранятся вторые производные z
+type tensionSpline struct {
+	points  []point
+	z       []float64 // Вторые производные сплайна в узлах
+	h       []float64 // Шаги h[i] = x[i+1] - x[i]
+	tension float64   // Безразмерное натяжение sigma = tau(i)*h(i), одинаковое для всех отрезков
+}
+
+// newTensionSpline создает натуральный сплайн с натяжением. При tension = 0 получается
+// обычный кубический сплайн, с ростом tension кривая приближается к ломаной и перестает
+// давать выбросы между узлами. tension должно быть неотрицательным конечным числом
+func newTensionSpline(data *interpolationData, tension float64) (*tensionSpline, error) {
+	if !(tension >= 0) || math.IsInf(tension, 1) {
+		return nil, fmt.Errorf("натяжение должно быть неотрицательным конечным числом, получено %g", tension)
+	}
+	points := data.points
+	if err := validateSplinePoints(points); err != nil {
+		return nil, err
+	}
+	n := len(points)
+
+	h := make([]float64, n-1)
+	delta := make([]float64, n-1)
+	for i := 0; i < n-1; i++ {
+		h[i] = points[i+1].x - points[i].x
+		delta[i] = (points[i+1].y - points[i].y) / h[i]
+	}
+
+	// Коэффициенты уравнений непрерывности первой производной:
+	// e(i-1)*z(i-1) + (d(i-1) + d(i))*z(i) + e(i)*z(i+1) = delta(i) - delta(i-1).
+	// При tension -> 0 e(i) -> h(i)/6, d(i) -> h(i)/3, как в системе кубического сплайна
+	offDiag, onDiag := tensionCoefficients(tension)
+	e := make([]float64, n-1)
+	d := make([]float64, n-1)
+	for i := 0; i < n-1; i++ {
+		e[i] = h[i] * offDiag
+		d[i] = h[i] * onDiag
+	}
+
+	lower := make([]float64, n)
+	diag := make([]float64, n)
+	upper := make([]float64, n)
+	rhs := make([]float64, n)
+
+	// Естественные граничные условия: z(0) = z(n) = 0
+	diag[0] = 1
+	diag[n-1] = 1
+	for i := 1; i < n-1; i++ {
+		lower[i] = e[i-1]
+		diag[i] = d[i-1] + d[i]
+		upper[i] = e[i]
+		rhs[i] = delta[i] - delta[i-1]
+	}
+
+	return &tensionSpline{
+		points:  points,
+		z:       solveTridiagonal(lower, diag, upper, rhs),
+		h:       h,
+		tension: tension,
+	}, nil
+}
+
+// tensionCoefficients возвращает (1 - sigma/sh(sigma))/sigma^2 и (sigma*cth(sigma) - 1)/sigma^2 -
+// внедиагональный и диагональный коэффициенты системы, отнесенные к длине отрезка
+func tensionCoefficients(sigma float64) (offDiag, diag float64) {
+	if sigma < tensionSmallSigma {
+		s2 := sigma * sigma
+		return 1.0/6 - 7*s2/360, 1.0/3 - s2/45
+	}
+	s2 := sigma * sigma
+	return (1 - sigma/math.Sinh(sigma)) / s2, (sigma/math.Tanh(sigma) - 1) / s2
+}
+
+// tensionBasis вычисляет (sh(sigma*u)/sh(sigma) - u)/sigma^2 для u из [0, 1] - вклад второй
+// производной в узле в значение сплайна. При sigma -> 0 переходит в (u^3 - u)/6 из формулы (2.61)
+func tensionBasis(sigma, u float64) float64 {
+	if sigma < tensionSmallSigma {
+		u2 := u * u
+		return u * ((u2-1)/6 + sigma*sigma*(u2-1)*(3*u2-7)/360)
+	}
+	// sh(sigma*u)/sh(sigma) через экспоненты, чтобы не переполняться при больших sigma
+	ratio := math.Exp(sigma*(u-1)) * math.Expm1(-2*sigma*u) / math.Expm1(-2*sigma)
+	return (ratio - u) / (sigma * sigma)
+}
+
+// evaluate вычисляет значение сплайна с натяжением в точке x
+func (ts *tensionSpline) evaluate(x float64) float64 {
+	i := findInterval(ts.points, x)
+	h := ts.h[i]
+
+	left := (ts.points[i+1].x - x) / h
+	right := (x - ts.points[i].x) / h
+
+	return ts.points[i].y*left + ts.points[i+1].y*right +
+		h*h*(ts.z[i]*tensionBasis(ts.tension, left)+ts.z[i+1]*tensionBasis(ts.tension, right))
+}
+
+// domain возвращает отрезок между крайними узлами сплайна
+func (ts *tensionSpline) domain() (lo, hi float64) {
+	return nodeRange(ts.points)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTensionSplineZeroIsCubic(t *testing.T) {
+	data, err := createGrid(1, 5, 10, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tension, err := newTensionSpline(data, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cubic, err := newCubicSpline(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, x := range linspace(data.a, data.b, 200) {
+		if got, want := tension.evaluate(x), cubic.evaluate(x); math.Abs(got-want) > 1e-12 {
+			t.Errorf("x = %g: сплайн с нулевым натяжением %.15g, кубический сплайн %.15g", x, got, want)
+		}
+	}
+}
+
+func TestTensionSplineApproachesLinear(t *testing.T) {
+	data, err := createGrid(-1, 1, 8, rungeFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Отклонение от ломаной убывает с ростом натяжения
+	prevDev := math.Inf(1)
+	for _, tension := range []float64{1, 10, 100, 1000} {
+		spline, err := newTensionSpline(data, tension)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dev := 0.0
+		for _, x := range linspace(data.a, data.b, 500) {
+			dev = math.Max(dev, math.Abs(spline.evaluate(x)-linearInterpolation(data, x)))
+		}
+		if !(dev < prevDev) {
+			t.Errorf("натяжение %g: отклонение от ломаной %.3e не меньше предыдущего %.3e", tension, dev, prevDev)
+		}
+		prevDev = dev
+	}
+	if prevDev > 1e-3 {
+		t.Errorf("при натяжении 1000 отклонение от ломаной %.3e", prevDev)
+	}
+}
+
+func TestTensionSplineInvalidTension(t *testing.T) {
+	data, err := createGrid(1, 5, 4, testFunction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tension := range []float64{-1, math.NaN(), math.Inf(1)} {
+		if _, err := newTensionSpline(data, tension); err == nil {
+			t.Errorf("натяжение %g: ожидалась ошибка", tension)
+		}
+	}
+}
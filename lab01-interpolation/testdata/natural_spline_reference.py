@@ -0,0 +1,79 @@
+#!/usr/bin/env python3
+"""Генерирует эталон для TestNaturalSplineReference независимо от Go-кода.
+
+Натуральный кубический сплайн строится в точной рациональной арифметике (fractions):
+вторые производные M находятся из трехдиагональной системы с M[0] = M[n] = 0,
+значения вычисляются по формуле (2.61). Узлы - тестовая функция x*log10(x+1) - 1
+на 11 равноотстоящих узлах [1, 5].
+
+Запуск из каталога testdata: python3 natural_spline_reference.py
+"""
+
+import csv
+import math
+from fractions import Fraction
+
+A, B, N = 1.0, 5.0, 10
+SAMPLES = 101
+
+
+def test_function(x):
+    return x * math.log10(x + 1) - 1
+
+
+def natural_spline(xs, ys):
+    n = len(xs) - 1
+    h = [xs[i + 1] - xs[i] for i in range(n)]
+
+    # Система для M[1..n-1] методом прогонки в точной арифметике
+    lower, diag, upper, rhs = [], [], [], []
+    for i in range(1, n):
+        lower.append(h[i - 1] / 6)
+        diag.append((h[i - 1] + h[i]) / 3)
+        upper.append(h[i] / 6)
+        rhs.append((ys[i + 1] - ys[i]) / h[i] - (ys[i] - ys[i - 1]) / h[i - 1])
+
+    m = len(diag)
+    for i in range(1, m):
+        w = lower[i] / diag[i - 1]
+        diag[i] -= w * upper[i - 1]
+        rhs[i] -= w * rhs[i - 1]
+    inner = [Fraction(0)] * m
+    for i in range(m - 1, -1, -1):
+        inner[i] = (rhs[i] - (upper[i] * inner[i + 1] if i < m - 1 else 0)) / diag[i]
+    second = [Fraction(0)] + inner + [Fraction(0)]
+
+    def evaluate(x):
+        i = 0
+        while i < n - 1 and x > xs[i + 1]:
+            i += 1
+        left, right = xs[i + 1] - x, x - xs[i]
+        return (second[i] * left ** 3 / (6 * h[i]) + second[i + 1] * right ** 3 / (6 * h[i])
+                + (ys[i] - second[i] * h[i] ** 2 / 6) * left / h[i]
+                + (ys[i + 1] - second[i + 1] * h[i] ** 2 / 6) * right / h[i])
+
+    return evaluate
+
+
+def main():
+    nodes_x = [A + i * (B - A) / N for i in range(N + 1)]
+    nodes_x[-1] = B
+    nodes = [(x, test_function(x)) for x in nodes_x]
+
+    with open("natural_spline_nodes.csv", "w", newline="") as f:
+        writer = csv.writer(f, lineterminator="\n")
+        writer.writerow(["x", "y"])
+        for x, y in nodes:
+            writer.writerow([repr(x), repr(y)])
+
+    evaluate = natural_spline([Fraction(x) for x, _ in nodes], [Fraction(y) for _, y in nodes])
+    with open("natural_spline_reference.csv", "w", newline="") as f:
+        writer = csv.writer(f, lineterminator="\n")
+        writer.writerow(["x", "expected"])
+        for k in range(SAMPLES):
+            x = A + k * (B - A) / (SAMPLES - 1)
+            writer.writerow([repr(x), repr(float(evaluate(Fraction(x))))])
+
+
+if __name__ == "__main__":
+    main()